@@ -3,7 +3,9 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/url"
+	"reflect"
 
 	"github.com/guardian360/go-lighthouse/client"
 )
@@ -14,9 +16,11 @@ type APIRequestPayload map[string]interface{}
 
 // APIRequestHandler is the base resource for all API resources.
 type APIRequestHandler struct {
-	Client  *client.Client
-	BaseURL string
-	params  url.Values
+	Client      *client.Client
+	BaseURL     string
+	params      url.Values
+	callOptions []client.CallOption
+	err         error
 }
 
 // SetParam sets a query parameter for the API request.
@@ -27,6 +31,29 @@ func (r *APIRequestHandler) SetParam(param, value string) {
 	r.params.Set(param, value)
 }
 
+// Param returns the value of a query parameter set with SetParam.
+func (r *APIRequestHandler) Param(param string) string {
+	return r.params.Get(param)
+}
+
+// WithParam returns a copy of the handler with a query parameter set,
+// leaving r unchanged.
+func (r APIRequestHandler) WithParam(param, value string) APIRequestHandler {
+	params := url.Values{}
+	for k, v := range r.params {
+		params[k] = append([]string(nil), v...)
+	}
+	r.params = params
+	r.SetParam(param, value)
+	return r
+}
+
+// SetCallOptions sets per-call client options, such as
+// client.SkipCoalescing, for the API request.
+func (r *APIRequestHandler) SetCallOptions(opts ...client.CallOption) {
+	r.callOptions = opts
+}
+
 // BuildURL constructs the full URL for the API request, including any query
 // parameters.
 func (r *APIRequestHandler) BuildURL() string {
@@ -37,8 +64,17 @@ func (r *APIRequestHandler) BuildURL() string {
 }
 
 // Do executes an API request with the specified method, URL, and payload.
+// If the client has a StrictMode other than client.StrictOff, the response is
+// checked for fields that T does not declare or that are missing from it.
+// Missing fields are not reported when the URL selects a sparse fieldset with
+// a fields[...] parameter, since the server leaves out the other fields.
+// If building the request failed, such as on an invalid filter, Do returns
+// that error without sending anything.
 func Do[T any](r APIRequestHandler, method, url string, data APIRequestPayload) (*T, error) {
-	resp, err := r.Client.Do(method, url, data)
+	if r.err != nil {
+		return nil, r.err
+	}
+	resp, err := r.Client.DoWithOptions(method, url, data, r.callOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -50,5 +86,21 @@ func Do[T any](r APIRequestHandler, method, url string, data APIRequestPayload)
 	if err := json.Unmarshal(bytes, &decoded); err != nil {
 		return nil, err
 	}
+	if r.Client.Strict != client.StrictOff {
+		var errs []error
+		sparse := selectsFields(url)
+		for _, d := range findDrift(reflect.TypeOf(decoded), map[string]interface{}(resp), "") {
+			if sparse {
+				if len(d.unknown) == 0 && len(d.invalid) == 0 {
+					continue
+				}
+				d.missing = nil
+			}
+			errs = append(errs, r.Client.ReportDrift(url, d.typ, d.unknown, d.missing, d.invalid))
+		}
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+	}
 	return &decoded, nil
 }
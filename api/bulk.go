@@ -0,0 +1,334 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/guardian360/go-lighthouse/client"
+)
+
+// defaultBulkConcurrency is the number of requests the bulk operations keep
+// in flight when BulkOptions.Concurrency is not set.
+const defaultBulkConcurrency = 4
+
+// BulkOptions configures CreateMany, UpdateMany and DeleteMany.
+type BulkOptions struct {
+	// Batch sends all items in a single request to the collection's batch
+	// endpoint, {collection}/batch. If the server answers 404 or 405, the
+	// items are sent one request at a time instead.
+	Batch bool
+	// Concurrency is the maximum number of requests in flight when items are
+	// sent one at a time. It defaults to 4.
+	Concurrency int
+	// Rate is the maximum number of requests started per second when items
+	// are sent one at a time. Zero means no limit; rates above one request
+	// per nanosecond are treated as one per nanosecond.
+	Rate float64
+}
+
+// BulkResult is the outcome for one item of CreateMany, UpdateMany or
+// DeleteMany.
+type BulkResult[T any] struct {
+	// Index is the position of the item in the input.
+	Index int
+	// ID is the ID of the resource, if known.
+	ID string
+	// Data is the created, updated or deleted resource, if the item succeeded
+	// and the server returned it.
+	Data *T
+	// Err is the error for the item, or nil if it succeeded.
+	Err error
+}
+
+// BulkItemError is the error for a single item of a bulk operation.
+type BulkItemError struct {
+	// Index is the position of the item in the input.
+	Index int
+	// ID is the ID of the resource, if known.
+	ID string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *BulkItemError) Error() string {
+	if e.ID != "" {
+		return fmt.Sprintf("api: item %d (%s): %v", e.Index, e.ID, e.Err)
+	}
+	return fmt.Sprintf("api: item %d: %v", e.Index, e.Err)
+}
+
+func (e *BulkItemError) Unwrap() error {
+	return e.Err
+}
+
+// CreateMany creates a resource for each input. A failing item does not stop
+// the others: every item gets a result, in input order, and the error joins a
+// *BulkItemError for each failed item, or is nil if all succeeded.
+func (r *CollectionOf[T, L, R]) CreateMany(inputs []APIRequestPayload, opts BulkOptions) ([]BulkResult[T], error) {
+	if opts.Batch {
+		if results, ok := r.createBatch(inputs); ok {
+			return results, bulkError(results)
+		}
+	}
+	h := r.itemHandler("")
+	results := fanOut(len(inputs), opts, func(i int) BulkResult[T] {
+		resp, err := Do[R](h, "POST", h.BaseURL, inputs[i])
+		if err != nil {
+			return BulkResult[T]{Index: i, Err: err}
+		}
+		data := (*resp).Value()
+		return BulkResult[T]{Index: i, ID: idOf(data), Data: &data}
+	})
+	return results, bulkError(results)
+}
+
+// BulkUpdate is an update of UpdateMany.
+type BulkUpdate struct {
+	// ID is the ID of the resource to update.
+	ID string
+	// Data is the payload to update the resource with.
+	Data APIRequestPayload
+}
+
+// UpdateMany updates resources, in the same way CreateMany creates them.
+// Each update is sent like Item.Update, with the collection's UpdateMethod.
+// Through the batch endpoint, the updates are sent with that method too, as
+// {"data": [...]} with the ID of each resource in its id field.
+func (r *CollectionOf[T, L, R]) UpdateMany(updates []BulkUpdate, opts BulkOptions) ([]BulkResult[T], error) {
+	if opts.Batch {
+		if results, ok := r.updateBatch(updates); ok {
+			return results, bulkError(results)
+		}
+	}
+	results := fanOut(len(updates), opts, func(i int) BulkResult[T] {
+		resp, err := r.item(updates[i].ID).Update(updates[i].Data)
+		if err != nil {
+			return BulkResult[T]{Index: i, ID: updates[i].ID, Err: err}
+		}
+		data := (*resp).Value()
+		return BulkResult[T]{Index: i, ID: updates[i].ID, Data: &data}
+	})
+	return results, bulkError(results)
+}
+
+// DeleteMany deletes the resources with the given IDs, in the same way
+// CreateMany creates them.
+func (r *CollectionOf[T, L, R]) DeleteMany(ids []string, opts BulkOptions) ([]BulkResult[T], error) {
+	if opts.Batch {
+		if results, ok := r.deleteBatch(ids); ok {
+			return results, bulkError(results)
+		}
+	}
+	results := fanOut(len(ids), opts, func(i int) BulkResult[T] {
+		h := r.itemHandler(ids[i])
+		resp, err := Do[R](h, "DELETE", h.BaseURL, nil)
+		if err != nil {
+			return BulkResult[T]{Index: i, ID: ids[i], Err: err}
+		}
+		data := (*resp).Value()
+		return BulkResult[T]{Index: i, ID: ids[i], Data: &data}
+	})
+	return results, bulkError(results)
+}
+
+// createBatch creates inputs through the batch endpoint. It reports false
+// if the server has no batch endpoint. Otherwise every item gets the
+// returned resource at its position, or the error of the request.
+func (r *CollectionOf[T, L, R]) createBatch(inputs []APIRequestPayload) ([]BulkResult[T], bool) {
+	h := r.itemHandler("batch")
+	resp, err := Do[L](h, "POST", h.BaseURL, APIRequestPayload{"data": inputs})
+	if noBatchEndpoint(err) {
+		return nil, false
+	}
+	var items []T
+	if err == nil {
+		items = (*resp).Items()
+	}
+	results := make([]BulkResult[T], len(inputs))
+	for i := range results {
+		results[i].Index = i
+		switch {
+		case err != nil:
+			results[i].Err = err
+		case i < len(items):
+			results[i].Data = &items[i]
+			results[i].ID = idOf(items[i])
+		default:
+			results[i].Err = errors.New("not returned by the batch endpoint")
+		}
+	}
+	return results, true
+}
+
+// deleteBatch deletes ids through the batch endpoint. It reports false if
+// the server has no batch endpoint. IDs missing from the response are
+// reported as not deleted.
+func (r *CollectionOf[T, L, R]) deleteBatch(ids []string) ([]BulkResult[T], bool) {
+	h := r.itemHandler("batch")
+	resp, err := Do[L](h, "DELETE", h.BaseURL, APIRequestPayload{"ids": ids})
+	if noBatchEndpoint(err) {
+		return nil, false
+	}
+	deleted := map[string]*T{}
+	if err == nil {
+		items := (*resp).Items()
+		for i := range items {
+			deleted[idOf(items[i])] = &items[i]
+		}
+	}
+	results := make([]BulkResult[T], len(ids))
+	for i, id := range ids {
+		results[i] = BulkResult[T]{Index: i, ID: id, Err: err}
+		if err != nil {
+			continue
+		}
+		if data, ok := deleted[id]; ok {
+			results[i].Data = data
+		} else {
+			results[i].Err = errors.New("not deleted by the batch endpoint")
+		}
+	}
+	return results, true
+}
+
+// updateBatch updates through the batch endpoint. It reports false if the
+// server has no batch endpoint. Updates of resources missing from the
+// response are reported as not applied.
+func (r *CollectionOf[T, L, R]) updateBatch(updates []BulkUpdate) ([]BulkResult[T], bool) {
+	data := make([]APIRequestPayload, len(updates))
+	for i, u := range updates {
+		data[i] = APIRequestPayload{"id": u.ID}
+		for k, v := range u.Data {
+			if k != "id" {
+				data[i][k] = v
+			}
+		}
+	}
+	batch := r.item("batch")
+	resp, err := Do[L](batch.APIRequestHandler, batch.updateMethod(), batch.BaseURL, APIRequestPayload{"data": data})
+	if noBatchEndpoint(err) {
+		return nil, false
+	}
+	updated := map[string]*T{}
+	if err == nil {
+		items := (*resp).Items()
+		for i := range items {
+			updated[idOf(items[i])] = &items[i]
+		}
+	}
+	results := make([]BulkResult[T], len(updates))
+	for i, u := range updates {
+		results[i] = BulkResult[T]{Index: i, ID: u.ID, Err: err}
+		if err != nil {
+			continue
+		}
+		if data, ok := updated[u.ID]; ok {
+			results[i].Data = data
+		} else {
+			results[i].Err = errors.New("not updated by the batch endpoint")
+		}
+	}
+	return results, true
+}
+
+// itemHandler returns a handler for the URL below the collection named by
+// segment, or for the collection itself if segment is empty, without the
+// collection's query parameters.
+func (r *CollectionOf[T, L, R]) itemHandler(segment string) APIRequestHandler {
+	h := APIRequestHandler{
+		Client:      r.Client,
+		BaseURL:     r.BaseURL,
+		callOptions: r.callOptions,
+	}
+	if segment != "" {
+		h.BaseURL += "/" + segment
+	}
+	return h
+}
+
+// item returns the item below the collection with the given ID, without the
+// collection's query parameters.
+func (r *CollectionOf[T, L, R]) item(id string) *ItemOf[T, R] {
+	return &ItemOf[T, R]{
+		APIRequestHandler: r.itemHandler(id),
+		ID:                id,
+		UpdateMethod:      r.UpdateMethod,
+	}
+}
+
+// noBatchEndpoint reports whether err means the server has no batch
+// endpoint.
+func noBatchEndpoint(err error) bool {
+	var apiErr *client.APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.IsNotFound() || apiErr.StatusCode == http.StatusMethodNotAllowed)
+}
+
+// fanOut runs do for each of n items with at most opts.Concurrency calls in
+// flight, starting at most opts.Rate calls per second, and returns the
+// results in item order.
+func fanOut[T any](n int, opts BulkOptions, do func(i int) BulkResult[T]) []BulkResult[T] {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = defaultBulkConcurrency
+	}
+	var tick <-chan time.Time
+	if opts.Rate > 0 {
+		ticker := time.NewTicker(max(time.Duration(float64(time.Second)/opts.Rate), time.Nanosecond))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	results := make([]BulkResult[T], n)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range n {
+		if tick != nil && i > 0 {
+			<-tick
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			results[i] = do(i)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// bulkError joins the errors of the failed results.
+func bulkError[T any](results []BulkResult[T]) error {
+	var errs []error
+	for _, res := range results {
+		if res.Err != nil {
+			errs = append(errs, &BulkItemError{Index: res.Index, ID: res.ID, Err: res.Err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// idOf returns the id field of a resource, or "" if it has none.
+func idOf[T any](v T) string {
+	f, ok := fieldValue(v, "id")
+	if !ok {
+		return ""
+	}
+	switch f.Kind() {
+	case reflect.String:
+		return f.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(f.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(f.Uint(), 10)
+	}
+	if s, ok := f.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFanOut_BoundsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	results := fanOut(10, BulkOptions{Concurrency: 3}, func(i int) BulkResult[int] {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		return BulkResult[int]{Index: i, Data: &i}
+	})
+
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	for i, res := range results {
+		assert.Equal(t, i, *res.Data)
+	}
+}
+
+func TestFanOut_RateLimits(t *testing.T) {
+	start := time.Now()
+	fanOut(5, BulkOptions{Rate: 100}, func(i int) BulkResult[int] {
+		return BulkResult[int]{Index: i}
+	})
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestFanOut_ClampsHighRates(t *testing.T) {
+	results := fanOut(3, BulkOptions{Rate: 1e12}, func(i int) BulkResult[int] {
+		return BulkResult[int]{Index: i}
+	})
+	assert.Len(t, results, 3)
+}
+
+func TestIDOf(t *testing.T) {
+	type embedded struct {
+		ID int `json:"id"`
+	}
+	assert.Equal(t, "a", idOf(struct {
+		ID FlexString `json:"id"`
+	}{"a"}))
+	assert.Equal(t, "7", idOf(struct{ embedded }{embedded{7}}))
+	assert.Equal(t, "7", idOf(&embedded{7}))
+	assert.Equal(t, "", idOf(struct{ Name string }{"a"}))
+	assert.Equal(t, "", idOf(3))
+}
+
+func TestBulkError(t *testing.T) {
+	boom := errors.New("boom")
+	err := bulkError([]BulkResult[int]{{Index: 0}, {Index: 1, ID: "b", Err: boom}, {Index: 2, Err: boom}})
+
+	assert.ErrorIs(t, err, boom)
+	assert.EqualError(t, err, "api: item 1 (b): boom\napi: item 2: boom")
+	assert.NoError(t, bulkError([]BulkResult[int]{{Index: 0}}))
+}
+
+func TestCollection_UpdateManyUsesUpdateMethod(t *testing.T) {
+	c, requests := newRecordingServer(t, `{"data":{"id":"1","name":"a"}}`)
+	widgets := NewCollection[widget](c, c.BaseURL+"/api/v2/widgets")
+	widgets.UpdateMethod = "PATCH"
+
+	_, err := widgets.UpdateMany([]BulkUpdate{{ID: "1", Data: APIRequestPayload{"name": "a"}}}, BulkOptions{})
+	require.NoError(t, err)
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "PATCH", (*requests)[0].Method)
+	assert.Equal(t, "/api/v2/widgets/1", (*requests)[0].URL)
+
+	c, requests = newRecordingServer(t, `{"data":[{"id":"1","name":"a"}]}`)
+	widgets = NewCollection[widget](c, c.BaseURL+"/api/v2/widgets")
+	widgets.UpdateMethod = "PATCH"
+	results, err := widgets.UpdateMany([]BulkUpdate{{ID: "1", Data: APIRequestPayload{"name": "a"}}}, BulkOptions{Batch: true})
+	require.NoError(t, err)
+	assert.Equal(t, "a", results[0].Data.Name)
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "PATCH", (*requests)[0].Method)
+	assert.Equal(t, "/api/v2/widgets/batch", (*requests)[0].URL)
+}
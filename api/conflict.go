@@ -0,0 +1,101 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/guardian360/go-lighthouse/client"
+)
+
+// ConflictError is returned by Item.UpdateIfUnchanged when the resource was
+// changed on the server after the expected version was read.
+type ConflictError[T any] struct {
+	// Current is the version of the resource on the server.
+	Current T
+}
+
+func (e *ConflictError[T]) Error() string {
+	if t, ok := updatedAt(e.Current); ok {
+		return fmt.Sprintf("api: resource was modified at %s since it was read", t)
+	}
+	return "api: resource was modified since it was read"
+}
+
+// UpdateIfUnchanged updates the resource like Update, but only if it has
+// not changed on the server since expected was read. The resource's
+// updated_at is compared with that of expected before writing. To reject a
+// change made between that check and the write, the write is sent with
+// If-Match if the server returned an ETag, and with If-Unmodified-Since
+// otherwise. If-Unmodified-Since only has a resolution of a second, and a
+// server that ignores it leaves the check and the write as two separate
+// steps, so a change made in between is overwritten. On a conflict nothing
+// is written and a *ConflictError[T] holding the current version is
+// returned:
+//
+//	_, err := lh.Probe(probe.ID).UpdateIfUnchanged(probe, payload)
+//	var conflict *api.ConflictError[v2.Probe]
+//	if errors.As(err, &conflict) {
+//		// Merge with conflict.Current and try again.
+//	}
+func (r *ItemOf[T, R]) UpdateIfUnchanged(expected T, data APIRequestPayload) (*R, error) {
+	want, ok := updatedAt(expected)
+	if !ok {
+		return nil, errors.New("api: UpdateIfUnchanged needs the updated_at of the expected version")
+	}
+
+	var header http.Header
+	current, err := r.fetchCurrent(client.ReadHeader(&header))
+	if err != nil {
+		return nil, err
+	}
+	if got, _ := updatedAt((*current).Value()); !got.Equal(want.Time) {
+		return nil, &ConflictError[T]{Current: (*current).Value()}
+	}
+
+	h := r.APIRequestHandler
+	precondition := client.WithHeader("If-Unmodified-Since", want.UTC().Format(http.TimeFormat))
+	if etag := header.Get("ETag"); etag != "" {
+		precondition = client.WithHeader("If-Match", etag)
+	}
+	h.callOptions = append(slices.Clone(h.callOptions), precondition)
+	resp, err := Do[R](h, r.updateMethod(), r.BuildURL(), data)
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed {
+		latest, getErr := r.fetchCurrent(client.SkipCoalescing())
+		if getErr != nil {
+			return nil, err
+		}
+		return nil, &ConflictError[T]{Current: (*latest).Value()}
+	}
+	return resp, err
+}
+
+// fetchCurrent retrieves the resource without the item's query parameters.
+func (r *ItemOf[T, R]) fetchCurrent(opts ...client.CallOption) (*R, error) {
+	h := APIRequestHandler{
+		Client:      r.Client,
+		BaseURL:     r.BaseURL,
+		callOptions: append(slices.Clone(r.callOptions), opts...),
+	}
+	return Do[R](h, "GET", h.BaseURL, nil)
+}
+
+// updatedAt returns the updated_at field of a resource and whether it is
+// set.
+func updatedAt[T any](v T) (Time, bool) {
+	f, ok := fieldValue(v, "updated_at")
+	if !ok || !f.CanInterface() {
+		return Time{}, false
+	}
+	switch t := f.Interface().(type) {
+	case Time:
+		return t, !t.IsZero()
+	case *Time:
+		if t != nil {
+			return *t, !t.IsZero()
+		}
+	}
+	return Time{}, false
+}
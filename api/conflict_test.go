@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type versionedWidget struct {
+	ID        string `json:"id"`
+	UpdatedAt Time   `json:"updated_at"`
+}
+
+func TestItem_UpdateIfUnchanged_WithoutETag(t *testing.T) {
+	c, requests := newRecordingServer(t, `{"data":{"id":"1","updated_at":"2024-01-01T10:00:00.250000Z"}}`)
+	expected := versionedWidget{ID: "1", UpdatedAt: Time{time.Date(2024, 1, 1, 10, 0, 0, 250000000, time.UTC)}}
+
+	_, err := NewItem[versionedWidget](c, c.BaseURL+"/api/v2/widgets/1", "1").UpdateIfUnchanged(expected, APIRequestPayload{"name": "a"})
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 2)
+	put := (*requests)[1]
+	assert.Equal(t, "PUT", put.Method)
+	assert.Equal(t, "Mon, 01 Jan 2024 10:00:00 GMT", put.Header.Get("If-Unmodified-Since"))
+	assert.Empty(t, put.Header.Get("If-Match"))
+}
+
+func TestItem_UpdateIfUnchanged_NeedsUpdatedAt(t *testing.T) {
+	c, requests := newRecordingServer(t, `{"data":{"id":"1"}}`)
+
+	_, err := NewItem[widget](c, c.BaseURL+"/api/v2/widgets/1", "1").UpdateIfUnchanged(widget{ID: "1"}, APIRequestPayload{})
+	require.Error(t, err)
+	assert.Empty(t, *requests)
+}
+
+func TestUpdatedAt(t *testing.T) {
+	at := Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	got, ok := updatedAt(versionedWidget{UpdatedAt: at})
+	assert.True(t, ok)
+	assert.True(t, got.Equal(at.Time))
+	got, ok = updatedAt(&struct {
+		UpdatedAt *Time `json:"updated_at"`
+	}{&at})
+	assert.True(t, ok)
+	assert.True(t, got.Equal(at.Time))
+	_, ok = updatedAt(versionedWidget{})
+	assert.False(t, ok)
+	_, ok = updatedAt(http.Header{})
+	assert.False(t, ok)
+}
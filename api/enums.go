@@ -0,0 +1,245 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ProbeStatus is the connection status of a probe or hacker alert appliance.
+type ProbeStatus string
+
+const (
+	ProbeStatusOnline  ProbeStatus = "online"
+	ProbeStatusOffline ProbeStatus = "offline"
+)
+
+// IsValid reports whether s is a known probe status.
+func (s ProbeStatus) IsValid() bool {
+	switch s {
+	case ProbeStatusOnline, ProbeStatusOffline:
+		return true
+	}
+	return false
+}
+
+// String returns the status as sent by the API.
+func (s ProbeStatus) String() string { return string(s) }
+
+// UnmarshalJSON decodes the status, keeping unknown values as is.
+func (s *ProbeStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*string)(s))
+}
+
+// NetworkType is the network configuration of a probe or hacker alert
+// appliance.
+type NetworkType string
+
+const (
+	NetworkTypeDHCP   NetworkType = "dhcp"
+	NetworkTypeStatic NetworkType = "static"
+)
+
+// IsValid reports whether t is a known network type.
+func (t NetworkType) IsValid() bool {
+	switch t {
+	case NetworkTypeDHCP, NetworkTypeStatic:
+		return true
+	}
+	return false
+}
+
+// String returns the network type as sent by the API.
+func (t NetworkType) String() string { return string(t) }
+
+// UnmarshalJSON decodes the network type, keeping unknown values as is.
+func (t *NetworkType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*string)(t))
+}
+
+// ScanObjectType is the kind of target a scan object describes.
+type ScanObjectType string
+
+const (
+	ScanObjectTypeIPv4      ScanObjectType = "ipv4"
+	ScanObjectTypeIPv4Range ScanObjectType = "ipv4-range"
+	ScanObjectTypeURL       ScanObjectType = "url"
+)
+
+// IsValid reports whether t is a known scan object type.
+func (t ScanObjectType) IsValid() bool {
+	switch t {
+	case ScanObjectTypeIPv4, ScanObjectTypeIPv4Range, ScanObjectTypeURL:
+		return true
+	}
+	return false
+}
+
+// String returns the scan object type as sent by the API.
+func (t ScanObjectType) String() string { return string(t) }
+
+// UnmarshalJSON decodes the scan object type, keeping unknown values as is.
+func (t *ScanObjectType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*string)(t))
+}
+
+// ScanTaskType distinguishes scheduled scan tasks from rescans.
+type ScanTaskType string
+
+const (
+	ScanTaskTypeScheduled ScanTaskType = "0"
+	ScanTaskTypeRescan    ScanTaskType = "1"
+)
+
+// IsValid reports whether t is a known scan task type.
+func (t ScanTaskType) IsValid() bool {
+	switch t {
+	case ScanTaskTypeScheduled, ScanTaskTypeRescan:
+		return true
+	}
+	return false
+}
+
+// String returns "scheduled" or "rescan", or the raw value if t is unknown.
+func (t ScanTaskType) String() string {
+	switch t {
+	case ScanTaskTypeScheduled:
+		return "scheduled"
+	case ScanTaskTypeRescan:
+		return "rescan"
+	}
+	return string(t)
+}
+
+// UnmarshalJSON decodes the scan task type from a string or number, keeping
+// unknown values as is.
+func (t *ScanTaskType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*string)(t))
+}
+
+// ScanTaskState is the lifecycle state of a scan task, derived from its
+// timestamps and error.
+type ScanTaskState string
+
+const (
+	ScanTaskStatePending ScanTaskState = "pending"
+	ScanTaskStateRunning ScanTaskState = "running"
+	ScanTaskStateStopped ScanTaskState = "stopped"
+	ScanTaskStateFailed  ScanTaskState = "failed"
+)
+
+// IsValid reports whether s is a known scan task state.
+func (s ScanTaskState) IsValid() bool {
+	switch s {
+	case ScanTaskStatePending, ScanTaskStateRunning, ScanTaskStateStopped, ScanTaskStateFailed:
+		return true
+	}
+	return false
+}
+
+// String returns the state name.
+func (s ScanTaskState) String() string { return string(s) }
+
+// NewScanTaskState derives the state of a scan task: failed if it reported
+// an error, pending until it has started, running until it has stopped and
+// stopped afterwards.
+func NewScanTaskState(startedAt, stoppedAt Time, errMsg string) ScanTaskState {
+	switch {
+	case errMsg != "":
+		return ScanTaskStateFailed
+	case startedAt.IsZero():
+		return ScanTaskStatePending
+	case stoppedAt.IsZero():
+		return ScanTaskStateRunning
+	}
+	return ScanTaskStateStopped
+}
+
+// ScannerPlatformType is the deployment kind of a scanner platform.
+type ScannerPlatformType string
+
+const (
+	ScannerPlatformTypePublic  ScannerPlatformType = "public"
+	ScannerPlatformTypePrivate ScannerPlatformType = "private"
+)
+
+// IsValid reports whether t is a known scanner platform type.
+func (t ScannerPlatformType) IsValid() bool {
+	switch t {
+	case ScannerPlatformTypePublic, ScannerPlatformTypePrivate:
+		return true
+	}
+	return false
+}
+
+// String returns the scanner platform type as sent by the API.
+func (t ScannerPlatformType) String() string { return string(t) }
+
+// UnmarshalJSON decodes the scanner platform type, keeping unknown values as
+// is.
+func (t *ScannerPlatformType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*string)(t))
+}
+
+// ExclusionType is the kind of value a scan object exclusion excludes. The
+// values are those of the type enum in openapi/lighthouse-v2.yaml; the
+// descriptions of the constants are how v2.ScanObjectExclusion.Matches
+// interprets them, not a server guarantee.
+type ExclusionType string
+
+const (
+	// ExclusionTypeIPv4 excludes a single IPv4 address.
+	ExclusionTypeIPv4 ExclusionType = "ipv4"
+	// ExclusionTypeIPv4Range excludes a CIDR block such as 10.0.0.0/24 or an
+	// address range such as 10.0.0.1-10.0.0.20.
+	ExclusionTypeIPv4Range ExclusionType = "ipv4-range"
+	// ExclusionTypeHostname excludes a hostname, or all subdomains for a
+	// wildcard such as *.example.com.
+	ExclusionTypeHostname ExclusionType = "hostname"
+	// ExclusionTypeURL excludes a URL and everything below its path.
+	ExclusionTypeURL ExclusionType = "url"
+	// ExclusionTypePort excludes a port such as 22 or a range such as
+	// 8000-8100.
+	ExclusionTypePort ExclusionType = "port"
+)
+
+// IsValid reports whether t is a known exclusion type.
+func (t ExclusionType) IsValid() bool {
+	switch t {
+	case ExclusionTypeIPv4, ExclusionTypeIPv4Range, ExclusionTypeHostname, ExclusionTypeURL, ExclusionTypePort:
+		return true
+	}
+	return false
+}
+
+// String returns the exclusion type as sent by the API.
+func (t ExclusionType) String() string { return string(t) }
+
+// UnmarshalJSON decodes the exclusion type, keeping unknown values as is.
+func (t *ExclusionType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*string)(t))
+}
+
+// unmarshalEnum decodes a JSON string, number or boolean into dst so that
+// values the API adds later, or sends with a different JSON type, decode
+// instead of failing. null decodes to "".
+func unmarshalEnum(data []byte, dst *string) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*dst = ""
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		return json.Unmarshal(data, dst)
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v.(type) {
+	case float64, bool:
+		*dst = string(data)
+		return nil
+	}
+	return fmt.Errorf("api: cannot decode %s as an enumerated value", data)
+}
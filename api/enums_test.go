@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnums_TolerantDecoding(t *testing.T) {
+	var v struct {
+		Status    ProbeStatus         `json:"status"`
+		Network   NetworkType         `json:"network_type"`
+		Object    ScanObjectType      `json:"object"`
+		Task      ScanTaskType        `json:"task"`
+		Platform  ScannerPlatformType `json:"platform"`
+		Exclusion ExclusionType       `json:"exclusion"`
+	}
+	data := `{"status":"rebooting","network_type":null,"object":"ipv4-range","task":1,"platform":"private","exclusion":"port"}`
+	require.NoError(t, json.Unmarshal([]byte(data), &v))
+
+	assert.Equal(t, ProbeStatus("rebooting"), v.Status)
+	assert.False(t, v.Status.IsValid())
+	assert.Equal(t, NetworkType(""), v.Network)
+	assert.Equal(t, ScanObjectTypeIPv4Range, v.Object)
+	assert.True(t, v.Object.IsValid())
+	assert.Equal(t, ScanTaskTypeRescan, v.Task)
+	assert.Equal(t, "rescan", v.Task.String())
+	assert.Equal(t, ScannerPlatformTypePrivate, v.Platform)
+	assert.Equal(t, ExclusionTypePort, v.Exclusion)
+	assert.True(t, v.Exclusion.IsValid())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"status":{}}`), &v))
+}
+
+func TestEnums_MarshalAsRawValue(t *testing.T) {
+	out, err := json.Marshal(struct {
+		Type ScanTaskType `json:"type"`
+	}{ScanTaskTypeScheduled})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"0"}`, string(out))
+}
+
+func TestNewScanTaskState(t *testing.T) {
+	started := NewTime(time.Now())
+	assert.Equal(t, ScanTaskStatePending, NewScanTaskState(Time{}, Time{}, ""))
+	assert.Equal(t, ScanTaskStateRunning, NewScanTaskState(started, Time{}, ""))
+	assert.Equal(t, ScanTaskStateStopped, NewScanTaskState(started, started, ""))
+	assert.Equal(t, ScanTaskStateFailed, NewScanTaskState(started, started, "timeout"))
+}
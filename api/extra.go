@@ -0,0 +1,326 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// extraFieldName is the name of the field models keep unknown JSON fields in.
+const extraFieldName = "Extra"
+
+var rawMessageMapType = reflect.TypeOf(map[string]json.RawMessage(nil))
+
+// UnmarshalWithExtra decodes data into v and stores the fields of data that v
+// does not declare in extra, or sets extra to nil if there are none. Models
+// call it from their UnmarshalJSON method with v pointing to a defined type
+// without methods, so that decoding does not recurse:
+//
+//	func (p *Probe) UnmarshalJSON(data []byte) error {
+//		type probe Probe
+//		return api.UnmarshalWithExtra(data, (*probe)(p), &p.Extra)
+//	}
+func UnmarshalWithExtra(data []byte, v interface{}, extra *map[string]json.RawMessage) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	*extra = nil
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		// Not an object, e.g. null; v has already reported any error.
+		return nil
+	}
+	known := jsonFieldsOf(reflect.TypeOf(v).Elem())
+	for name, value := range fields {
+		if known.has(name) {
+			continue
+		}
+		if *extra == nil {
+			*extra = map[string]json.RawMessage{}
+		}
+		(*extra)[name] = value
+	}
+	return nil
+}
+
+// MarshalWithExtra encodes v and merges the fields in extra into the
+// result, so that unknown fields survive a decode/encode round trip. Fields
+// declared by v take precedence over extra. Models call it from their
+// MarshalJSON method, again with v of a defined type without methods.
+func MarshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// jsonField is a field of a struct as seen by encoding/json.
+type jsonField struct {
+	name     string
+	typ      reflect.Type
+	index    []int
+	optional bool
+}
+
+type jsonFields []jsonField
+
+// has reports whether a JSON key decodes into one of the fields, matching
+// names case-insensitively like encoding/json does.
+func (fs jsonFields) has(key string) bool {
+	for _, f := range fs {
+		if f.name == key || strings.EqualFold(f.name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+var jsonFieldsCache sync.Map // reflect.Type -> jsonFields
+
+// jsonFieldsOf returns the JSON fields of struct type t, including those
+// promoted from embedded structs.
+func jsonFieldsOf(t reflect.Type) jsonFields {
+	if cached, ok := jsonFieldsCache.Load(t); ok {
+		return cached.(jsonFields)
+	}
+	var fields jsonFields
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for _, f := range jsonFieldsOf(ft) {
+					f.index = append([]int{i}, f.index...)
+					fields = append(fields, f)
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, jsonField{
+			name:     name,
+			typ:      sf.Type,
+			index:    []int{i},
+			optional: strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero"),
+		})
+	}
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+// fieldValue returns the field of struct v with the JSON name name, or false
+// if v has no such field.
+func fieldValue(v interface{}, name string) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	for _, f := range jsonFieldsOf(rv.Type()) {
+		if f.name == name {
+			fv, err := rv.FieldByIndexErr(f.index)
+			return fv, err == nil
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// FieldNames returns the JSON names of the fields of struct type t, including
+// those promoted from embedded structs, in declaration order.
+func FieldNames(t reflect.Type) []string {
+	fields := jsonFieldsOf(t)
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// isModel reports whether struct type t keeps unknown fields in Extra.
+func isModel(t reflect.Type) bool {
+	f, ok := t.FieldByName(extraFieldName)
+	return ok && f.Type == rawMessageMapType
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// drift describes fields of a decoded JSON object that did not match the
+// struct type it was decoded into.
+type drift struct {
+	typ                       string
+	unknown, missing, invalid []string
+}
+
+// findDrift walks the generic JSON value raw alongside type t and returns
+// the unknown, missing and invalid fields of every JSON object decoded into
+// a struct. Fields are invalid when a lenient type such as FlexInt could not
+// make sense of their value and fell back to the zero value.
+// Structs with custom decoding, such as Time, are treated as opaque unless
+// they are models. Anonymous structs are named after the field holding them.
+func findDrift(t reflect.Type, raw interface{}, name string) []drift {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil
+		}
+		var out []drift
+		for _, item := range items {
+			out = mergeDrift(out, findDrift(t.Elem(), item, name))
+		}
+		return out
+	case reflect.Map:
+		values, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		var out []drift
+		for _, value := range values {
+			out = mergeDrift(out, findDrift(t.Elem(), value, name))
+		}
+		return out
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	object, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if !isModel(t) && reflect.PointerTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+	if t.Name() != "" {
+		name = t.String()
+	}
+
+	fields := jsonFieldsOf(t)
+	d := drift{typ: name}
+	var out []drift
+	for key := range object {
+		if !fields.has(key) {
+			d.unknown = append(d.unknown, key)
+		}
+	}
+	for _, f := range fields {
+		value, present := lookupKey(object, f.name)
+		if !present {
+			if !f.optional {
+				d.missing = append(d.missing, f.name)
+			}
+			continue
+		}
+		if !decodesCleanly(f.typ, value) {
+			d.invalid = append(d.invalid, f.name)
+			continue
+		}
+		out = mergeDrift(out, findDrift(f.typ, value, name+"."+f.name))
+	}
+	if len(d.unknown) > 0 || len(d.missing) > 0 || len(d.invalid) > 0 {
+		sort.Strings(d.unknown)
+		sort.Strings(d.missing)
+		sort.Strings(d.invalid)
+		out = mergeDrift([]drift{d}, out)
+	}
+	return out
+}
+
+// decodesCleanly reports whether the generic JSON value raw decodes into t
+// without falling back to a zero value. Only lenient types are checked.
+func decodesCleanly(t reflect.Type, raw interface{}) bool {
+	for t.Kind() == reflect.Pointer {
+		if raw == nil {
+			return true
+		}
+		t = t.Elem()
+	}
+	if !reflect.PointerTo(t).Implements(lenientType) {
+		return true
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return false
+	}
+	return reflect.New(t).Interface().(lenient).decodes(data)
+}
+
+// lookupKey finds key in object, falling back to a case-insensitive match.
+func lookupKey(object map[string]interface{}, key string) (interface{}, bool) {
+	if value, ok := object[key]; ok {
+		return value, true
+	}
+	for k, value := range object {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// mergeDrift appends b to a, combining entries for the same type.
+func mergeDrift(a, b []drift) []drift {
+	for _, d := range b {
+		merged := false
+		for i := range a {
+			if a[i].typ == d.typ {
+				a[i].unknown = mergeNames(a[i].unknown, d.unknown)
+				a[i].missing = mergeNames(a[i].missing, d.missing)
+				a[i].invalid = mergeNames(a[i].invalid, d.invalid)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			a = append(a, d)
+		}
+	}
+	return a
+}
+
+func mergeNames(a, b []string) []string {
+	for _, name := range b {
+		found := false
+		for _, existing := range a {
+			if existing == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			a = append(a, name)
+		}
+	}
+	sort.Strings(a)
+	return a
+}
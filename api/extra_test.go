@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testModel struct {
+	ID       string                     `json:"id"`
+	Name     string                     `json:"name,omitempty"`
+	Children []testModel                `json:"children,omitempty"`
+	Owner    struct{ ID string }        `json:"owner,omitzero"`
+	Created  Time                       `json:"created_at"`
+	Extra    map[string]json.RawMessage `json:"-"`
+}
+
+func (m *testModel) UnmarshalJSON(data []byte) error {
+	type model testModel
+	return UnmarshalWithExtra(data, (*model)(m), &m.Extra)
+}
+
+func (m testModel) MarshalJSON() ([]byte, error) {
+	type model testModel
+	return MarshalWithExtra(model(m), m.Extra)
+}
+
+func TestUnmarshalWithExtra(t *testing.T) {
+	data := `{"id":"1","NAME":"probe","firmware":"1.2","tags":["a"],"created_at":null}`
+	var m testModel
+	require.NoError(t, json.Unmarshal([]byte(data), &m))
+
+	assert.Equal(t, "1", m.ID)
+	assert.Equal(t, "probe", m.Name, "keys match case-insensitively like encoding/json")
+	assert.Equal(t, map[string]json.RawMessage{
+		"firmware": json.RawMessage(`"1.2"`),
+		"tags":     json.RawMessage(`["a"]`),
+	}, m.Extra)
+
+	out, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1","name":"probe","firmware":"1.2","tags":["a"],"created_at":null}`, string(out))
+}
+
+func TestUnmarshalWithExtra_NoUnknownFields(t *testing.T) {
+	var m testModel
+	m.Extra = map[string]json.RawMessage{"stale": json.RawMessage(`1`)}
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"1","created_at":null}`), &m))
+	assert.Nil(t, m.Extra)
+}
+
+func TestFindDrift(t *testing.T) {
+	type response struct {
+		Data []testModel `json:"data"`
+	}
+	var raw interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"data": [
+			{"id": "1", "created_at": null, "firmware": "1.2", "children": [{"id": "2", "extra": true}]},
+			{"created_at": null, "owner": {"ID": "3", "kind": "company"}}
+		],
+		"meta": {}
+	}`), &raw))
+
+	drifts := findDrift(reflect.TypeOf(response{}), raw, "")
+	assert.ElementsMatch(t, []drift{
+		{typ: "api.response", unknown: []string{"meta"}},
+		{typ: "api.testModel", unknown: []string{"extra", "firmware"}, missing: []string{"created_at", "id"}},
+		{typ: "api.testModel.owner", unknown: []string{"kind"}},
+	}, drifts)
+}
+
+func TestFindDrift_InvalidValues(t *testing.T) {
+	type record struct {
+		Port    FlexInt   `json:"port"`
+		Enabled *FlexBool `json:"enabled"`
+		Count   FlexInt   `json:"count"`
+	}
+	var raw interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"port": 1.5, "enabled": "maybe", "count": "12.0"}`), &raw))
+
+	drifts := findDrift(reflect.TypeOf(record{}), raw, "")
+	assert.Equal(t, []drift{
+		{typ: "api.record", invalid: []string{"enabled", "port"}},
+	}, drifts)
+}
+
+func TestFieldNames(t *testing.T) {
+	assert.Equal(t, []string{"id", "name", "children", "owner", "created_at"}, FieldNames(reflect.TypeOf(testModel{})))
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FlexInt is an int that also decodes from numeric strings, fractional
+// numbers with no fractional part, booleans (1 and 0) and null or "" (0).
+// The Lighthouse API is not consistent in how it encodes numeric fields, so
+// models use FlexInt wherever the type has been seen to vary. Any other
+// value, such as 1.5 or "n/a", decodes as 0 and is reported as an invalid
+// field by strict mode. It encodes as a JSON number.
+type FlexInt int
+
+// Int returns i as an int.
+func (i FlexInt) Int() int { return int(i) }
+
+// UnmarshalJSON decodes a number, numeric string, boolean or null. Values
+// that are not integers decode as 0.
+func (i *FlexInt) UnmarshalJSON(data []byte) error {
+	n, _ := parseFlexInt(data)
+	*i = FlexInt(n)
+	return nil
+}
+
+// decodes reports whether data holds a value FlexInt understands.
+func (*FlexInt) decodes(data []byte) bool {
+	_, ok := parseFlexInt(data)
+	return ok
+}
+
+// parseFlexInt decodes data as a FlexInt and reports whether it could.
+func parseFlexInt(data []byte) (int, bool) {
+	raw, err := flexScalar(data)
+	if err != nil {
+		return 0, false
+	}
+	raw = strings.TrimSpace(raw)
+	switch raw {
+	case "", "null", "false":
+		return 0, true
+	case "true":
+		return 1, true
+	}
+	if n, err := strconv.Atoi(raw); err == nil {
+		return n, true
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// FlexBool is a bool that also decodes from numbers (non-zero is true),
+// the strings "true", "false", "1", "0", "yes", "no", "on" and "off", and
+// null or "" (false). Any other value decodes as false and is reported as an
+// invalid field by strict mode. It encodes as a JSON boolean.
+type FlexBool bool
+
+// Bool returns b as a bool.
+func (b FlexBool) Bool() bool { return bool(b) }
+
+// UnmarshalJSON decodes a boolean, number, boolean-like string or null.
+// Other values decode as false.
+func (b *FlexBool) UnmarshalJSON(data []byte) error {
+	v, _ := parseFlexBool(data)
+	*b = FlexBool(v)
+	return nil
+}
+
+// decodes reports whether data holds a value FlexBool understands.
+func (*FlexBool) decodes(data []byte) bool {
+	_, ok := parseFlexBool(data)
+	return ok
+}
+
+// parseFlexBool decodes data as a FlexBool and reports whether it could.
+func parseFlexBool(data []byte) (bool, bool) {
+	raw, err := flexScalar(data)
+	if err != nil {
+		return false, false
+	}
+	raw = strings.TrimSpace(raw)
+	switch strings.ToLower(raw) {
+	case "", "null", "false", "0", "no", "off":
+		return false, true
+	case "true", "1", "yes", "on":
+		return true, true
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return false, false
+	}
+	return f != 0, true
+}
+
+// lenient is implemented by types that decode any JSON value without error,
+// falling back to their zero value. decodes reports whether data is a value
+// the type actually understands, so drift checks can report the others.
+type lenient interface {
+	decodes(data []byte) bool
+}
+
+var lenientType = reflect.TypeOf((*lenient)(nil)).Elem()
+
+// FlexString is a string that also decodes from numbers and booleans, using
+// their JSON text, and from null (""). It encodes as a JSON string.
+type FlexString string
+
+// String returns s as a string.
+func (s FlexString) String() string { return string(s) }
+
+// UnmarshalJSON decodes a string, number, boolean or null.
+func (s *FlexString) UnmarshalJSON(data []byte) error {
+	raw, err := flexScalar(data)
+	if err != nil {
+		return fmt.Errorf("api: cannot decode %s as a string", data)
+	}
+	if raw == "null" && bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		raw = ""
+	}
+	*s = FlexString(raw)
+	return nil
+}
+
+// flexScalar returns the contents of a JSON string, or the literal text of
+// any other scalar. Arrays and objects are rejected.
+func flexScalar(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "", fmt.Errorf("empty value")
+	}
+	switch data[0] {
+	case '"':
+		var s string
+		err := json.Unmarshal(data, &s)
+		return s, err
+	case '[', '{':
+		return "", fmt.Errorf("not a scalar")
+	}
+	if !json.Valid(data) {
+		return "", fmt.Errorf("invalid JSON")
+	}
+	return string(data), nil
+}
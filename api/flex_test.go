@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlexInt(t *testing.T) {
+	for input, want := range map[string]FlexInt{
+		`443`:     443,
+		`"443"`:   443,
+		`" 443 "`: 443,
+		`443.0`:   443,
+		`true`:    1,
+		`false`:   0,
+		`null`:    0,
+		`""`:      0,
+	} {
+		var got FlexInt
+		require.NoError(t, json.Unmarshal([]byte(input), &got), input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{`"https"`, `1.5`, `"12.5"`, `[1]`} {
+		got := FlexInt(7)
+		require.NoError(t, json.Unmarshal([]byte(input), &got), input)
+		assert.Equal(t, FlexInt(0), got, input)
+		assert.False(t, got.decodes([]byte(input)), input)
+	}
+	var valid FlexInt
+	assert.True(t, valid.decodes([]byte(`"12.0"`)))
+}
+
+func TestFlexBool(t *testing.T) {
+	for input, want := range map[string]FlexBool{
+		`true`:   true,
+		`false`:  false,
+		`1`:      true,
+		`0`:      false,
+		`2`:      true,
+		`"1"`:    true,
+		`"0"`:    false,
+		`"TRUE"`: true,
+		`"yes"`:  true,
+		`"off"`:  false,
+		`null`:   false,
+		`""`:     false,
+	} {
+		var got FlexBool
+		require.NoError(t, json.Unmarshal([]byte(input), &got), input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{`"maybe"`, `{}`} {
+		got := FlexBool(true)
+		require.NoError(t, json.Unmarshal([]byte(input), &got), input)
+		assert.Equal(t, FlexBool(false), got, input)
+		assert.False(t, got.decodes([]byte(input)), input)
+	}
+}
+
+func TestFlexString(t *testing.T) {
+	for input, want := range map[string]FlexString{
+		`"2048"`: "2048",
+		`2048`:   "2048",
+		`true`:   "true",
+		`null`:   "",
+		`"null"`: "null",
+	} {
+		var got FlexString
+		require.NoError(t, json.Unmarshal([]byte(input), &got), input)
+		assert.Equal(t, want, got, input)
+	}
+}
+
+func TestFlex_PageSurvivesSchemaWobble(t *testing.T) {
+	type scanObject struct {
+		Port FlexInt  `json:"port"`
+		SSL  FlexBool `json:"ssl"`
+	}
+	var page []scanObject
+	data := `[{"port":443,"ssl":true},{"port":"8443","ssl":1},{"port":null,"ssl":"0"}]`
+	require.NoError(t, json.Unmarshal([]byte(data), &page))
+	assert.Equal(t, []scanObject{{443, true}, {8443, true}, {0, false}}, page)
+
+	out, err := json.Marshal(page[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"port":8443,"ssl":true}`, string(out))
+}
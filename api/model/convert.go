@@ -0,0 +1,203 @@
+package model
+
+import (
+	v1 "github.com/guardian360/go-lighthouse/api/v1"
+	v2 "github.com/guardian360/go-lighthouse/api/v2"
+)
+
+// CompanyFromV1 converts a v1 company.
+func CompanyFromV1(c v1.Company) Company {
+	return Company{
+		ID:        c.ID,
+		Name:      c.Name,
+		Email:     c.Email,
+		Website:   c.Website,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+		DeletedAt: c.DeletedAt,
+	}
+}
+
+// CompanyFromV2 converts a v2 company.
+func CompanyFromV2(c v2.Company) Company {
+	return Company{
+		ID:        c.ID,
+		Name:      c.Name,
+		Email:     c.Email,
+		Website:   c.Website,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+		DeletedAt: c.DeletedAt,
+	}
+}
+
+// ProbeFromV1 converts a v1 probe.
+func ProbeFromV1(p v1.Probe) Probe {
+	return Probe{
+		ID:              p.ID,
+		Name:            p.Name,
+		Description:     p.Description,
+		Hypervisor:      p.Hypervisor,
+		NetworkType:     p.NetworkType,
+		IPv4:            p.IPv4,
+		Subnet:          p.Subnet,
+		Gateway:         p.Gateway,
+		DNS:             dns(p.DNS1, p.DNS2, p.DNS3),
+		Status:          p.Status,
+		CPUCores:        p.CPUCores.Int(),
+		Memory:          p.Memory.String(),
+		Company:         fromV1Relation(p.Company, CompanyFromV1),
+		ScannerPlatform: fromV1Relation(p.ScannerPlatform, ScannerPlatformFromV1),
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+// ProbeFromV2 converts a v2 probe.
+func ProbeFromV2(p v2.Probe) Probe {
+	return Probe{
+		ID:              p.ID,
+		Name:            p.Name,
+		Description:     p.Description,
+		Hypervisor:      p.Hypervisor,
+		NetworkType:     p.NetworkType,
+		IPv4:            p.IPv4,
+		Subnet:          p.Subnet,
+		Gateway:         p.Gateway,
+		DNS:             dns(p.DNS1, p.DNS2, p.DNS3),
+		Status:          p.Status,
+		CPUCores:        p.CPUCores.Int(),
+		Memory:          p.Memory.String(),
+		ScannerVersion:  p.ScannerVersion,
+		Company:         fromV2Pointer(p.Company, CompanyFromV2),
+		ScannerPlatform: fromV2Pointer(p.ScannerPlatform, ScannerPlatformFromV2),
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+		DeletedAt:       p.DeletedAt,
+	}
+}
+
+// ScanObjectFromV1 converts a v1 scan object.
+func ScanObjectFromV1(s v1.ScanObject) ScanObject {
+	return ScanObject{
+		ID:              s.ID,
+		Name:            s.Name,
+		Value:           s.Value,
+		Description:     s.Description,
+		Type:            s.Type,
+		Port:            s.Port.Int(),
+		SSL:             s.SSL.Bool(),
+		Enabled:         s.Enabled.Bool(),
+		Company:         fromV1Relation(s.Company, CompanyFromV1),
+		ScannerPlatform: fromV1Relation(s.ScannerPlatform, ScannerPlatformFromV1),
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+		DeletedAt:       s.DeletedAt,
+	}
+}
+
+// ScanObjectFromV2 converts a v2 scan object.
+func ScanObjectFromV2(s v2.ScanObject) ScanObject {
+	return ScanObject{
+		ID:              s.ID,
+		Name:            s.Name,
+		Value:           s.Value,
+		Description:     s.Description,
+		Type:            s.Type,
+		Port:            s.Port.Int(),
+		SSL:             s.SSL.Bool(),
+		Enabled:         s.Enabled.Bool(),
+		Company:         fromV2Pointer(s.Company, CompanyFromV2),
+		ScannerPlatform: fromV2Pointer(s.ScannerPlatform, ScannerPlatformFromV2),
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+		DeletedAt:       s.DeletedAt,
+	}
+}
+
+// ScheduleFromV1 converts a v1 schedule.
+func ScheduleFromV1(s v1.Schedule) Schedule {
+	return Schedule{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		From:        s.From,
+		To:          s.To,
+		Active:      s.Active.Bool(),
+	}
+}
+
+// ScheduleFromV2 converts a v2 schedule.
+func ScheduleFromV2(s v2.Schedule) Schedule {
+	return Schedule{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		From:        s.From,
+		To:          s.To,
+		Active:      s.Active.Bool(),
+		Company:     fromV2Pointer(s.Company, CompanyFromV2),
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+		DeletedAt:   s.DeletedAt,
+	}
+}
+
+// ScannerPlatformFromV1 converts a v1 scanner platform. v1 only returns the
+// ID of the owning company.
+func ScannerPlatformFromV1(s v1.ScannerPlatform) ScannerPlatform {
+	p := ScannerPlatform{
+		ID:          s.ID,
+		Description: s.Description,
+	}
+	if s.CompanyID != "" {
+		p.Company = &Company{ID: s.CompanyID}
+	}
+	return p
+}
+
+// ScannerPlatformFromV2 converts a v2 scanner platform.
+func ScannerPlatformFromV2(s v2.ScannerPlatform) ScannerPlatform {
+	return ScannerPlatform{
+		ID:        s.ID,
+		Name:      s.Name,
+		Type:      s.Type,
+		Endpoint:  s.Endpoint,
+		Company:   fromV2Pointer(s.Company, CompanyFromV2),
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+		DeletedAt: s.DeletedAt,
+	}
+}
+
+// fromV1Relation converts a v1 relation, or returns nil if it is not
+// loaded.
+func fromV1Relation[S, T any](r v1.Relation[S], convert func(S) T) *T {
+	v, ok := r.Get()
+	if !ok {
+		return nil
+	}
+	t := convert(v)
+	return &t
+}
+
+// fromV2Pointer converts an included v2 resource, or returns nil if it was
+// not included.
+func fromV2Pointer[S, T any](s *S, convert func(S) T) *T {
+	if s == nil {
+		return nil
+	}
+	t := convert(*s)
+	return &t
+}
+
+// dns returns the configured DNS servers, leaving out empty ones.
+func dns(servers ...string) []string {
+	var out []string
+	for _, s := range servers {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+// Package model provides Lighthouse resources that do not depend on an API
+// version, with converters from the v1 and v2 types. Code written against
+// these types keeps working while resources are moved from v1 to v2 one at a
+// time.
+package model
+
+import "github.com/guardian360/go-lighthouse/api"
+
+// Company is a company, independent of the API version.
+type Company struct {
+	// ID is the unique identifier for the company.
+	ID string
+	// Name is the name of the company.
+	Name string
+	// Email is the general email address of the company.
+	Email string
+	// Website is the website of the company.
+	Website string
+	// CreatedAt is the timestamp when the company was created.
+	CreatedAt api.Time
+	// UpdatedAt is the timestamp when the company was last updated.
+	UpdatedAt api.Time
+	// DeletedAt is the timestamp when the company was deleted, if applicable.
+	DeletedAt api.Time
+}
+
+// Probe is a probe, independent of the API version.
+type Probe struct {
+	// ID is the unique identifier for the probe.
+	ID string
+	// Name is the name of the probe.
+	Name string
+	// Description is a description of the probe.
+	Description string
+	// Hypervisor is the type of hypervisor used by the probe.
+	Hypervisor string
+	// NetworkType is the type of network configuration for the probe.
+	NetworkType api.NetworkType
+	// IPv4 is the IPv4 address of the probe.
+	IPv4 string
+	// Subnet is the subnet mask of the probe's network configuration.
+	Subnet string
+	// Gateway is the gateway address of the probe's network configuration.
+	Gateway string
+	// DNS contains the configured DNS servers, in order of preference.
+	DNS []string
+	// Status is the current status of the probe.
+	Status api.ProbeStatus
+	// CPUCores is the number of CPU cores allocated to the probe.
+	CPUCores int
+	// Memory is the amount of memory allocated to the probe, in MB.
+	Memory string
+	// ScannerVersion is the version of the scanner running on the probe. It
+	// is only known in v2.
+	ScannerVersion string
+	// Company is the company that owns the probe, if it was included.
+	Company *Company
+	// ScannerPlatform is the scanner platform of the probe, if it was
+	// included.
+	ScannerPlatform *ScannerPlatform
+	// CreatedAt is the timestamp when the probe was created.
+	CreatedAt api.Time
+	// UpdatedAt is the timestamp when the probe was last updated.
+	UpdatedAt api.Time
+	// DeletedAt is the timestamp when the probe was deleted, if applicable.
+	// It is only known in v2.
+	DeletedAt api.Time
+}
+
+// ScanObject is a scan object, independent of the API version.
+type ScanObject struct {
+	// ID is the unique identifier for the scan object.
+	ID string
+	// Name is the name of the scan object.
+	Name string
+	// Value is the value of the scan object, such as an IP address or URL.
+	Value string
+	// Description is a description of the scan object.
+	Description string
+	// Type is the type of the scan object.
+	Type api.ScanObjectType
+	// Port is the port of the scan object, if applicable.
+	Port int
+	// SSL indicates whether the scan object uses SSL/TLS.
+	SSL bool
+	// Enabled indicates whether the scan object is enabled for scanning.
+	Enabled bool
+	// Company is the company that owns the scan object, if it was included.
+	Company *Company
+	// ScannerPlatform is the scanner platform of the scan object, if it was
+	// included.
+	ScannerPlatform *ScannerPlatform
+	// CreatedAt is the timestamp when the scan object was created.
+	CreatedAt api.Time
+	// UpdatedAt is the timestamp when the scan object was last updated.
+	UpdatedAt api.Time
+	// DeletedAt is the timestamp when the scan object was deleted, if
+	// applicable.
+	DeletedAt api.Time
+}
+
+// Schedule is a schedule, independent of the API version.
+type Schedule struct {
+	// ID is the unique identifier for the schedule.
+	ID string
+	// Name is the name of the schedule.
+	Name string
+	// Description is a description of the schedule.
+	Description string
+	// From is the start time of the schedule.
+	From string
+	// To is the end time of the schedule.
+	To string
+	// Active indicates whether the schedule is active.
+	Active bool
+	// Company is the company that owns the schedule, if it was included. It
+	// is only known in v2.
+	Company *Company
+	// CreatedAt is the timestamp when the schedule was created. It is only
+	// known in v2.
+	CreatedAt api.Time
+	// UpdatedAt is the timestamp when the schedule was last updated. It is
+	// only known in v2.
+	UpdatedAt api.Time
+	// DeletedAt is the timestamp when the schedule was deleted, if
+	// applicable. It is only known in v2.
+	DeletedAt api.Time
+}
+
+// ScannerPlatform is a scanner platform, independent of the API version.
+type ScannerPlatform struct {
+	// ID is the unique identifier for the scanner platform.
+	ID string
+	// Name is the name of the scanner platform. It is only known in v2.
+	Name string
+	// Description is a description of the scanner platform. It is only
+	// known in v1.
+	Description string
+	// Type is the deployment kind of the scanner platform. It is only known
+	// in v2.
+	Type api.ScannerPlatformType
+	// Endpoint is the endpoint of the scanner platform. It is only known in
+	// v2.
+	Endpoint string
+	// Company is the company that owns the scanner platform. From v1 only
+	// its ID is known.
+	Company *Company
+	// CreatedAt is the timestamp when the scanner platform was created. It
+	// is only known in v2.
+	CreatedAt api.Time
+	// UpdatedAt is the timestamp when the scanner platform was last updated.
+	// It is only known in v2.
+	UpdatedAt api.Time
+	// DeletedAt is the timestamp when the scanner platform was deleted, if
+	// applicable. It is only known in v2.
+	DeletedAt api.Time
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/api"
+	v1 "github.com/guardian360/go-lighthouse/api/v1"
+	v2 "github.com/guardian360/go-lighthouse/api/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanObject_SameFromBothVersions(t *testing.T) {
+	old := v1.ScanObject{
+		ID: "1", Name: "web", Value: "https://10.0.0.1", Type: api.ScanObjectTypeURL,
+		Port: 443, SSL: true, Enabled: true, Reference: "ticket-1",
+		Company: v1.NewRelation(v1.Company{ID: "c1", Name: "ACME", Telephone: "555"}),
+	}
+	current := v2.ScanObject{
+		ID: "1", Name: "web", Value: "https://10.0.0.1", Type: api.ScanObjectTypeURL,
+		Port: 443, SSL: true, Enabled: true,
+		Company: &v2.Company{ID: "c1", Name: "ACME"},
+	}
+
+	assert.Equal(t, ScanObjectFromV1(old), ScanObjectFromV2(current))
+	assert.Nil(t, ScanObjectFromV2(v2.ScanObject{}).Company)
+	assert.Nil(t, ScanObjectFromV1(v1.ScanObject{}).Company)
+}
+
+func TestProbeFromV1(t *testing.T) {
+	p := ProbeFromV1(v1.Probe{
+		ID: "p1", DNS1: "1.1.1.1", DNS3: "9.9.9.9", CPUCores: 4, Memory: "4096",
+		ScannerPlatform: v1.NewRelation(v1.ScannerPlatform{ID: "s1", CompanyID: "c1"}),
+	})
+
+	assert.Equal(t, []string{"1.1.1.1", "9.9.9.9"}, p.DNS)
+	assert.Equal(t, 4, p.CPUCores)
+	assert.Equal(t, "4096", p.Memory)
+	assert.Equal(t, &ScannerPlatform{ID: "s1", Company: &Company{ID: "c1"}}, p.ScannerPlatform)
+}
+
+func TestCompatibilityReport(t *testing.T) {
+	report := CompatibilityReport()
+
+	assert.Contains(t, report, MissingField{Resource: "Probe", Field: "activeContract"})
+	assert.Contains(t, report, MissingField{Resource: "ScanObject", Field: "reference"})
+	assert.Contains(t, report, MissingField{Resource: "ScannerPlatform", Field: "scanobjects"})
+	assert.NotContains(t, report, MissingField{Resource: "ScannerPlatform", Field: "company_id"})
+	assert.NotContains(t, report, MissingField{Resource: "ScanObject", Field: "ssl"})
+	for _, f := range report {
+		assert.NotEqual(t, "HackerAlertAppliance", f.Resource, "resources without a model are not reported")
+	}
+	assert.Equal(t, "Probe.activeContract", MissingField{Resource: "Probe", Field: "activeContract"}.String())
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"reflect"
+
+	"github.com/guardian360/go-lighthouse/api"
+	v1 "github.com/guardian360/go-lighthouse/api/v1"
+	v2 "github.com/guardian360/go-lighthouse/api/v2"
+)
+
+// MissingField is a field of a v1 resource that has no counterpart in v2.
+type MissingField struct {
+	// Resource is the name of the resource, such as "Probe".
+	Resource string
+	// Field is the JSON name of the v1 field, such as "activeContract".
+	Field string
+}
+
+// String returns the field as Resource.field.
+func (f MissingField) String() string {
+	return f.Resource + "." + f.Field
+}
+
+// resourcePairs lists the resources that have a model and exist in both
+// versions, in report order.
+var resourcePairs = []struct {
+	name   string
+	v1, v2 reflect.Type
+}{
+	{"Company", reflect.TypeOf(v1.Company{}), reflect.TypeOf(v2.Company{})},
+	{"Probe", reflect.TypeOf(v1.Probe{}), reflect.TypeOf(v2.Probe{})},
+	{"ScanObject", reflect.TypeOf(v1.ScanObject{}), reflect.TypeOf(v2.ScanObject{})},
+	{"ScannerPlatform", reflect.TypeOf(v1.ScannerPlatform{}), reflect.TypeOf(v2.ScannerPlatform{})},
+	{"Schedule", reflect.TypeOf(v1.Schedule{}), reflect.TypeOf(v2.Schedule{})},
+}
+
+// counterparts maps v1 fields to the v2 field holding the same data under
+// another name, per resource.
+var counterparts = map[string]map[string]string{
+	"ScannerPlatform": {"company_id": "company"},
+}
+
+// CompatibilityReport lists the v1 fields that have no v2 counterpart, for
+// the resources that have a model. Their values are lost when moving a
+// resource to v2. Fields are ordered by resource, then as declared in the v1
+// type.
+func CompatibilityReport() []MissingField {
+	var missing []MissingField
+	for _, pair := range resourcePairs {
+		v2Fields := map[string]bool{}
+		for _, name := range api.FieldNames(pair.v2) {
+			v2Fields[name] = true
+		}
+		for _, name := range api.FieldNames(pair.v1) {
+			counterpart := name
+			if renamed, ok := counterparts[pair.name][name]; ok {
+				counterpart = renamed
+			}
+			if !v2Fields[counterpart] {
+				missing = append(missing, MissingField{Resource: pair.name, Field: name})
+			}
+		}
+	}
+	return missing
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"iter"
+	"strconv"
+)
+
+// Paginate iterates over the resources on all pages of a listing, starting
+// at page first, or at the first page if first is not a page number. fetch
+// retrieves a page and returns its resources and the number of the last page.
+// Iteration stops after the last page, at an empty page, or when fetch fails,
+// in which case the error is yielded once.
+func Paginate[T any](first int, fetch func(page int) ([]T, int, error)) iter.Seq2[T, error] {
+	if first < 1 {
+		first = 1
+	}
+	return func(yield func(T, error) bool) {
+		for page := first; ; page++ {
+			data, lastPage, err := fetch(page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, v := range data {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			if len(data) == 0 || page >= lastPage {
+				return
+			}
+		}
+	}
+}
+
+// All iterates over the resources on all pages of the collection, starting
+// at the page set with Page. Filters, sorts and the page size apply to every
+// page:
+//
+//	for probe, err := range lh.Probes().PerPage(100).All() {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(probe.Name)
+//	}
+func (r *CollectionOf[T, L, R]) All() iter.Seq2[T, error] {
+	first, _ := strconv.Atoi(r.Param("page"))
+	return Paginate(first, func(page int) ([]T, int, error) {
+		h := r.WithParam("page", strconv.Itoa(page))
+		resp, err := Do[L](h, "GET", h.BuildURL(), nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		return (*resp).Items(), (*resp).LastPage(), nil
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	pages := map[int][]int{1: {1, 2}, 2: {3, 4}, 3: {5}}
+	var fetched []int
+	fetch := func(page int) ([]int, int, error) {
+		fetched = append(fetched, page)
+		return pages[page], 3, nil
+	}
+
+	var got []int
+	for v, err := range Paginate(0, fetch) {
+		assert.NoError(t, err)
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, got)
+	assert.Equal(t, []int{1, 2, 3}, fetched)
+
+	fetched, got = nil, nil
+	for v := range Paginate(2, fetch) {
+		got = append(got, v)
+		if v == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []int{3}, got)
+	assert.Equal(t, []int{2}, fetched)
+}
+
+func TestPaginate_StopsAtEmptyPageAndError(t *testing.T) {
+	calls := 0
+	for range Paginate(1, func(page int) ([]int, int, error) {
+		calls++
+		return nil, 10, nil
+	}) {
+		t.Fatal("unexpected resource")
+	}
+	assert.Equal(t, 1, calls)
+
+	boom := errors.New("boom")
+	var errs []error
+	for _, err := range Paginate(1, func(page int) ([]int, int, error) {
+		if page == 2 {
+			return nil, 0, boom
+		}
+		return []int{page}, 3, nil
+	}) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	assert.Equal(t, []error{boom}, errs)
+}
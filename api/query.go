@@ -0,0 +1,229 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Field is a field of the resource type T that can be filtered, sorted or
+// selected on. Resource packages declare the fields their endpoints accept:
+//
+//	var ScanResultHost = api.NewField[ScanResult]("host")
+//
+// Because the resource type is part of the field type, using a field with a
+// collection of another type fails to compile.
+type Field[T any] struct {
+	name string
+}
+
+// NewField declares the field of T with the given JSON name.
+func NewField[T any](name string) Field[T] {
+	return Field[T]{name: name}
+}
+
+// Name returns the JSON name of the field.
+func (f Field[T]) Name() string {
+	return f.name
+}
+
+// Condition is a filter on a field of T, created by the methods of Field.
+type Condition[T any] struct {
+	field  Field[T]
+	op     string
+	values []string
+	err    error
+}
+
+// Sort is a sort order on a field of T, created by Field.Asc and Field.Desc.
+type Sort[T any] struct {
+	field Field[T]
+	desc  bool
+}
+
+// Eq matches resources whose field equals value. It is encoded as
+// filter[field]=value. The API splits the value on commas, so a value that
+// contains one cannot be matched exactly; the request then fails with an
+// error instead of matching either part.
+func (f Field[T]) Eq(value interface{}) Condition[T] {
+	return f.In(value)
+}
+
+// In matches resources whose field equals one of values. It is encoded as
+// filter[field]=a,b. Values containing a comma are rejected as with Eq.
+func (f Field[T]) In(values ...interface{}) Condition[T] {
+	c := Condition[T]{field: f}
+	for _, v := range values {
+		s := formatValue(v)
+		if strings.Contains(s, ",") && c.err == nil {
+			c.err = fmt.Errorf("api: filter value %q for %s contains a comma", s, f.name)
+		}
+		c.values = append(c.values, s)
+	}
+	return c
+}
+
+// Gt matches resources whose field is greater than value. It is encoded as
+// filter[field][gt]=value.
+func (f Field[T]) Gt(value interface{}) Condition[T] {
+	return Condition[T]{field: f, op: "gt", values: []string{formatValue(value)}}
+}
+
+// Gte matches resources whose field is greater than or equal to value. It is
+// encoded as filter[field][gte]=value.
+func (f Field[T]) Gte(value interface{}) Condition[T] {
+	return Condition[T]{field: f, op: "gte", values: []string{formatValue(value)}}
+}
+
+// Lt matches resources whose field is less than value. It is encoded as
+// filter[field][lt]=value.
+func (f Field[T]) Lt(value interface{}) Condition[T] {
+	return Condition[T]{field: f, op: "lt", values: []string{formatValue(value)}}
+}
+
+// Lte matches resources whose field is less than or equal to value. It is
+// encoded as filter[field][lte]=value.
+func (f Field[T]) Lte(value interface{}) Condition[T] {
+	return Condition[T]{field: f, op: "lte", values: []string{formatValue(value)}}
+}
+
+// Asc sorts by the field in ascending order.
+func (f Field[T]) Asc() Sort[T] {
+	return Sort[T]{field: f}
+}
+
+// Desc sorts by the field in descending order.
+func (f Field[T]) Desc() Sort[T] {
+	return Sort[T]{field: f, desc: true}
+}
+
+// Param returns the query parameter name and value of the condition, such
+// as filter[created_at][gte] and 2024-01-01T00:00:00.000000Z.
+func (c Condition[T]) Param() (string, string) {
+	name := "filter[" + c.field.name + "]"
+	if c.op != "" {
+		name += "[" + c.op + "]"
+	}
+	return name, strings.Join(c.values, ",")
+}
+
+// Err returns the error of a condition that cannot be encoded, such as an Eq
+// on a value containing a comma.
+func (c Condition[T]) Err() error {
+	return c.err
+}
+
+// String returns the sort in the query parameter form, such as
+// -matched_at.
+func (s Sort[T]) String() string {
+	if s.desc {
+		return sortParam(s.field.name, "desc")
+	}
+	return sortParam(s.field.name, "asc")
+}
+
+// sortParam encodes a sort on key in the form the API expects: the key,
+// prefixed with a minus sign for descending order. Multiple sorts are joined
+// with commas, the first taking precedence, as in -created_at,name.
+func sortParam(key, order string) string {
+	if strings.EqualFold(order, "desc") {
+		return "-" + key
+	}
+	return key
+}
+
+// formatValue formats a filter value, using TimeFormat for timestamps.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case Time:
+		return v.UTC().Format(TimeFormat)
+	case time.Time:
+		return v.UTC().Format(TimeFormat)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Where filters the collection on the given conditions. If a condition cannot
+// be encoded, requests of the collection fail with its error.
+func (r *CollectionOf[T, L, R]) Where(conditions ...Condition[T]) *CollectionOf[T, L, R] {
+	for _, c := range conditions {
+		if c.err != nil && r.err == nil {
+			r.err = c.err
+		}
+		r.SetParam(c.Param())
+	}
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *CollectionOf[T, L, R]) SortBy(sorts ...Sort[T]) *CollectionOf[T, L, R] {
+	keys := make([]string, len(sorts))
+	for i, s := range sorts {
+		keys[i] = s.String()
+	}
+	r.SetParam("sort", strings.Join(keys, ","))
+	return r
+}
+
+// Fields limits the fields included for each resource. It is encoded as
+// fields[resource]=a,b, where resource is the last segment of the collection
+// URL.
+func (r *CollectionOf[T, L, R]) Fields(fields ...Field[T]) *CollectionOf[T, L, R] {
+	r.SetParam(fieldsParam(r.BaseURL, fields))
+	return r
+}
+
+// Fields limits the fields included in the resource. It is encoded as
+// fields[resource]=a,b, where resource is the collection segment of the
+// resource URL.
+func (r *ItemOf[T, R]) Fields(fields ...Field[T]) *ItemOf[T, R] {
+	r.SetParam(fieldsParam(path.Dir(r.BaseURL), fields))
+	return r
+}
+
+func fieldsParam[T any](collectionURL string, fields []Field[T]) (string, string) {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.name
+	}
+	return "fields[" + path.Base(collectionURL) + "]", strings.Join(names, ",")
+}
+
+// GetAs retrieves a page of the collection decoded as S, a lighter projection
+// of T such as a summary type. Only the fields S declares are requested, as
+// with Fields, so large resources transfer and decode a fraction of the data.
+// Filters, sorts and pagination set on c apply as usual, and c itself is
+// left unchanged:
+//
+//	page, err := api.GetAs[v2.ScanResultSummary](lh.ScanResults().Where(v2.ScanResultHost.Eq(host)))
+func GetAs[S, T any](c CollectionAPI[T]) (*List[S], error) {
+	r := c.collection()
+	var fields []Field[T]
+	for _, f := range jsonFieldsOf(reflect.TypeOf((*S)(nil)).Elem()) {
+		fields = append(fields, NewField[T](f.name))
+	}
+	h := r.WithParam(fieldsParam(r.BaseURL, fields))
+	return Do[List[S]](h, "GET", h.BuildURL(), nil)
+}
+
+// selectsFields reports whether rawURL selects a sparse fieldset with a
+// fields[...] parameter.
+func selectsFields(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	for key := range u.Query() {
+		if strings.HasPrefix(key, "fields[") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testModelName    = NewField[testModel]("name")
+	testModelCreated = NewField[testModel]("created_at")
+)
+
+func query(t *testing.T, rawURL string) url.Values {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return u.Query()
+}
+
+func TestCollection_Where(t *testing.T) {
+	c := client.New("http://example.com")
+	since := NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	q := query(t, NewCollection[testModel](c, c.BaseURL+"/api/v2/models").
+		Where(testModelName.Eq("web"), testModelCreated.Gte(since)).
+		BuildURL())
+	assert.Equal(t, "web", q.Get("filter[name]"))
+	assert.Equal(t, "2024-01-02T03:04:05.000000Z", q.Get("filter[created_at][gte]"))
+
+	q = query(t, NewCollection[testModel](c, c.BaseURL+"/api/v2/models").
+		Where(testModelName.In("a", "b"), testModelCreated.Lt(time.Unix(0, 0))).
+		BuildURL())
+	assert.Equal(t, "a,b", q.Get("filter[name]"))
+	assert.Equal(t, "1970-01-01T00:00:00.000000Z", q.Get("filter[created_at][lt]"))
+}
+
+func TestCollection_WhereRejectsCommas(t *testing.T) {
+	c, requests := newRecordingServer(t, `{"data":[]}`)
+
+	for _, cond := range []Condition[testModel]{testModelName.Eq("a,b"), testModelName.In("a", "b,c")} {
+		require.Error(t, cond.Err())
+		_, err := NewCollection[testModel](c, c.BaseURL+"/api/v2/models").Where(cond).Get()
+		assert.EqualError(t, err, cond.Err().Error())
+	}
+	assert.Empty(t, *requests)
+	assert.NoError(t, testModelName.In("a", "b").Err())
+}
+
+func TestCollection_SortByAndFields(t *testing.T) {
+	c := client.New("http://example.com")
+
+	q := query(t, NewCollection[testModel](c, c.BaseURL+"/api/v2/models").
+		SortBy(testModelCreated.Desc(), testModelName.Asc()).
+		Fields(testModelName, testModelCreated).
+		BuildURL())
+	assert.Equal(t, "-created_at,name", q.Get("sort"))
+	assert.Equal(t, "name,created_at", q.Get("fields[models]"))
+
+	q = query(t, NewItem[testModel](c, c.BaseURL+"/api/v2/models/1", "1").Fields(testModelName).BuildURL())
+	assert.Equal(t, "name", q.Get("fields[models]"))
+}
+
+func TestGetAs_LeavesCollectionUnchanged(t *testing.T) {
+	type summary struct {
+		Name string `json:"name"`
+	}
+	c, requests := newRecordingServer(t, `{"data":[{"name":"a"}]}`)
+	models := NewCollection[testModel](c, c.BaseURL+"/api/v2/models").Where(testModelName.Eq("a"))
+
+	page, err := GetAs[summary](models)
+	require.NoError(t, err)
+	assert.Equal(t, []summary{{Name: "a"}}, page.Data)
+
+	q := query(t, (*requests)[0].URL)
+	assert.Equal(t, "a", q.Get("filter[name]"))
+	assert.Equal(t, "name", q.Get("fields[models]"))
+	assert.False(t, query(t, models.BuildURL()).Has("fields[models]"))
+}
+
+func TestDo_SparseFieldsetsInStrictMode(t *testing.T) {
+	c, _ := newRecordingServer(t, `{"data":[{"id":"1"}]}`)
+	c.Strict = client.StrictError
+	widgets := func() *Collection[widget] { return NewCollection[widget](c, c.BaseURL+"/api/v2/widgets") }
+
+	_, err := widgets().Get()
+	var drift *client.DriftError
+	require.ErrorAs(t, err, &drift)
+	assert.Equal(t, []string{"name"}, drift.Missing)
+
+	list, err := widgets().Fields(NewField[widget]("id")).Get()
+	require.NoError(t, err)
+	assert.Equal(t, "1", list.Data[0].ID)
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/guardian360/go-lighthouse/client"
+)
+
+// List is a page of resources as returned by collection endpoints.
+type List[T any] struct {
+	// Data contains the resources on the page.
+	Data []T `json:"data"`
+	// Links contains pagination and other links.
+	Links PageLinks `json:"links,omitempty"`
+	// Meta contains metadata about the page.
+	Meta PageMeta `json:"meta,omitempty"`
+}
+
+// Response is the response for a single resource.
+type Response[T any] struct {
+	// Data contains the resource.
+	Data T `json:"data"`
+}
+
+// PageLinks contains pagination links for a List.
+type PageLinks struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Prev  string `json:"prev"`
+	Next  string `json:"next"`
+}
+
+// PageMeta contains pagination information for a List.
+type PageMeta struct {
+	CurrentPage int            `json:"current_page"`
+	From        int            `json:"from"`
+	LastPage    int            `json:"last_page"`
+	Links       []PageMetaLink `json:"links"`
+	Path        string         `json:"path"`
+	PerPage     int            `json:"per_page"`
+	To          int            `json:"to"`
+	Total       int            `json:"total"`
+}
+
+// PageMetaLink represents a link in the pagination metadata of a List.
+type PageMetaLink struct {
+	URL    string `json:"url"`
+	Label  string `json:"label"`
+	Active bool   `json:"active"`
+}
+
+// Items returns the resources on the page.
+func (l List[T]) Items() []T {
+	return l.Data
+}
+
+// LastPage returns the number of the last page.
+func (l List[T]) LastPage() int {
+	return l.Meta.LastPage
+}
+
+// Value returns the resource.
+func (r Response[T]) Value() T {
+	return r.Data
+}
+
+// Listing is implemented by the envelopes collection endpoints respond with,
+// such as List.
+type Listing[T any] interface {
+	// Items returns the resources in the envelope.
+	Items() []T
+	// LastPage returns the number of the last page, or 0 if the listing is
+	// not paginated.
+	LastPage() int
+}
+
+// Single is implemented by the envelopes single resource endpoints respond
+// with, such as Response.
+type Single[T any] interface {
+	// Value returns the resource in the envelope.
+	Value() T
+}
+
+// CollectionOf is the API for a collection of resources of type T whose
+// endpoints respond with the envelopes L and R. It carries the methods of
+// Collection, which is CollectionOf with the v2 envelopes; other API versions
+// define their own alias with their own envelopes.
+type CollectionOf[T any, L Listing[T], R Single[T]] struct {
+	APIRequestHandler
+	// UpdateMethod is the HTTP method UpdateMany uses, like the UpdateMethod
+	// of the collection's items. It defaults to PUT.
+	UpdateMethod string
+}
+
+// Collection is the API for a collection of resources of type T, such as
+// /api/v2/probes. Resources the library does not cover yet can be used by
+// creating a Collection for their URL:
+//
+//	type Widget struct {
+//		ID   string `json:"id"`
+//		Name string `json:"name"`
+//	}
+//
+//	widgets, err := api.NewCollection[Widget](c, c.BaseURL+"/api/v2/widgets").Get()
+type Collection[T any] = CollectionOf[T, List[T], Response[T]]
+
+// NewCollection creates a Collection for the resources at url.
+func NewCollection[T any](c *client.Client, url string) *Collection[T] {
+	return NewCollectionOf[T, List[T], Response[T]](c, url)
+}
+
+// NewCollectionOf creates a CollectionOf for the resources at url.
+func NewCollectionOf[T any, L Listing[T], R Single[T]](c *client.Client, url string) *CollectionOf[T, L, R] {
+	return &CollectionOf[T, L, R]{
+		APIRequestHandler: APIRequestHandler{
+			Client:  c,
+			BaseURL: url,
+		},
+	}
+}
+
+// CollectionAPI is implemented by Collection and by the resource types
+// embedding it, such as v2.ProbesAPI.
+type CollectionAPI[T any] interface {
+	collection() *Collection[T]
+}
+
+func (r *CollectionOf[T, L, R]) collection() *CollectionOf[T, L, R] {
+	return r
+}
+
+// Get retrieves a page of resources.
+func (r *CollectionOf[T, L, R]) Get() (*L, error) {
+	return Do[L](r.APIRequestHandler, "GET", r.BuildURL(), nil)
+}
+
+// Create creates a resource.
+func (r *CollectionOf[T, L, R]) Create(data APIRequestPayload) (*R, error) {
+	return Do[R](r.APIRequestHandler, "POST", r.BuildURL(), data)
+}
+
+// Page sets the page number for pagination.
+func (r *CollectionOf[T, L, R]) Page(page int) *CollectionOf[T, L, R] {
+	r.SetParam("page", fmt.Sprintf("%d", page))
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *CollectionOf[T, L, R]) PerPage(perPage int) *CollectionOf[T, L, R] {
+	r.SetParam("per_page", fmt.Sprintf("%d", perPage))
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *CollectionOf[T, L, R]) Scopes(scopes ...string) *CollectionOf[T, L, R] {
+	r.SetParam("scopes", strings.Join(scopes, ","))
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *CollectionOf[T, L, R]) With(relationships ...string) *CollectionOf[T, L, R] {
+	r.SetParam("with", strings.Join(relationships, ","))
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *CollectionOf[T, L, R]) Sort(sort, order string) *CollectionOf[T, L, R] {
+	r.SetParam("sort", sortParam(sort, order))
+	return r
+}
+
+// ItemOf is the API for a single resource of type T whose endpoints respond
+// with the envelope R. It carries the methods of Item, which is ItemOf with
+// the v2 envelope.
+type ItemOf[T any, R Single[T]] struct {
+	APIRequestHandler
+	// ID is the unique identifier for the resource.
+	ID string
+	// UpdateMethod is the HTTP method Update uses. It defaults to PUT.
+	UpdateMethod string
+}
+
+// Item is the API for a single resource of type T, such as
+// /api/v2/probes/{id}.
+type Item[T any] = ItemOf[T, Response[T]]
+
+// NewItem creates an Item for the resource with the given ID at url.
+func NewItem[T any](c *client.Client, url, id string) *Item[T] {
+	return NewItemOf[T, Response[T]](c, url, id)
+}
+
+// NewItemOf creates an ItemOf for the resource with the given ID at url.
+func NewItemOf[T any, R Single[T]](c *client.Client, url, id string) *ItemOf[T, R] {
+	return &ItemOf[T, R]{
+		APIRequestHandler: APIRequestHandler{
+			Client:  c,
+			BaseURL: url,
+		},
+		ID: id,
+	}
+}
+
+// Get retrieves the resource.
+func (r *ItemOf[T, R]) Get() (*R, error) {
+	return Do[R](r.APIRequestHandler, "GET", r.BuildURL(), nil)
+}
+
+// Update updates the resource with the given payload.
+func (r *ItemOf[T, R]) Update(data APIRequestPayload) (*R, error) {
+	return Do[R](r.APIRequestHandler, r.updateMethod(), r.BuildURL(), data)
+}
+
+// updateMethod returns the HTTP method for updates.
+func (r *ItemOf[T, R]) updateMethod() string {
+	if r.UpdateMethod == "" {
+		return "PUT"
+	}
+	return r.UpdateMethod
+}
+
+// Delete deletes the resource.
+func (r *ItemOf[T, R]) Delete() (*R, error) {
+	return Do[R](r.APIRequestHandler, "DELETE", r.BuildURL(), nil)
+}
+
+// With sets the relationships to include in the response.
+func (r *ItemOf[T, R]) With(relationships ...string) *ItemOf[T, R] {
+	r.SetParam("with", strings.Join(relationships, ","))
+	return r
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type widget struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// recordedRequest is a request received by newRecordingServer.
+type recordedRequest struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   map[string]interface{}
+}
+
+// newRecordingServer starts a server answering every request with body and
+// records the requests it receives.
+func newRecordingServer(t *testing.T, body string) (*client.Client, *[]recordedRequest) {
+	t.Helper()
+	var requests []recordedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := recordedRequest{Method: r.Method, URL: r.URL.String(), Header: r.Header.Clone()}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			require.NoError(t, json.Unmarshal(data, &req.Body))
+		}
+		requests = append(requests, req)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return client.New(srv.URL), &requests
+}
+
+func TestCollection_Get(t *testing.T) {
+	c, requests := newRecordingServer(t, `{"data":[{"id":"1","name":"a"},{"id":"2","name":"b"}],"meta":{"current_page":2,"last_page":3}}`)
+
+	list, err := NewCollection[widget](c, c.BaseURL+"/api/v2/widgets").
+		Page(2).
+		PerPage(2).
+		Scopes("active").
+		With("company").
+		Sort("name", "asc").
+		Get()
+	require.NoError(t, err)
+
+	assert.Equal(t, []widget{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}, list.Data)
+	assert.Equal(t, 3, list.Meta.LastPage)
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "GET", (*requests)[0].Method)
+	q := query(t, (*requests)[0].URL)
+	assert.Equal(t, "2", q.Get("page"))
+	assert.Equal(t, "2", q.Get("per_page"))
+	assert.Equal(t, "active", q.Get("scopes"))
+	assert.Equal(t, "company", q.Get("with"))
+	assert.Equal(t, "name", q.Get("sort"))
+}
+
+func TestCollection_Create(t *testing.T) {
+	c, requests := newRecordingServer(t, `{"data":{"id":"1","name":"a"}}`)
+
+	resp, err := NewCollection[widget](c, c.BaseURL+"/api/v2/widgets").Create(APIRequestPayload{"name": "a"})
+	require.NoError(t, err)
+
+	assert.Equal(t, widget{ID: "1", Name: "a"}, resp.Data)
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "POST", (*requests)[0].Method)
+	assert.Equal(t, "/api/v2/widgets", (*requests)[0].URL)
+	assert.Equal(t, map[string]interface{}{"name": "a"}, (*requests)[0].Body)
+}
+
+func TestItem_Methods(t *testing.T) {
+	c, requests := newRecordingServer(t, `{"data":{"id":"1","name":"a"}}`)
+	item := NewItem[widget](c, c.BaseURL+"/api/v2/widgets/1", "1")
+
+	resp, err := item.With("company").Get()
+	require.NoError(t, err)
+	assert.Equal(t, widget{ID: "1", Name: "a"}, resp.Data)
+
+	_, err = item.Update(APIRequestPayload{"name": "b"})
+	require.NoError(t, err)
+	item.UpdateMethod = "PATCH"
+	_, err = item.Update(APIRequestPayload{"name": "c"})
+	require.NoError(t, err)
+	_, err = item.Delete()
+	require.NoError(t, err)
+
+	var methods []string
+	for _, r := range *requests {
+		methods = append(methods, r.Method+" "+r.URL)
+	}
+	assert.Equal(t, []string{
+		"GET /api/v2/widgets/1?with=company",
+		"PUT /api/v2/widgets/1?with=company",
+		"PATCH /api/v2/widgets/1?with=company",
+		"DELETE /api/v2/widgets/1?with=company",
+	}, methods)
+	assert.Equal(t, map[string]interface{}{"name": "c"}, (*requests)[2].Body)
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimeFormat is the layout the Lighthouse API uses for timestamps, and the
+// layout Time is marshalled with.
+const TimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// timeLayouts are the layouts accepted when decoding a Time, in the order
+// they are tried. Timestamps without a zone are interpreted as UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Time is a timestamp returned by the Lighthouse API. It decodes the layouts
+// the API emits (RFC 3339 with or without fractional seconds, the
+// "2006-01-02 15:04:05" database layout, plain dates and Unix timestamps),
+// treats null and "" as the zero time and encodes the zero time as null.
+type Time struct {
+	time.Time
+}
+
+// NewTime returns t as a Time.
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// ParseTime parses a timestamp in any of the layouts the API emits. An empty
+// string yields the zero Time.
+func ParseTime(s string) (Time, error) {
+	if s == "" {
+		return Time{}, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Time{Time: t}, nil
+		}
+	}
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Time{Time: time.Unix(secs, 0).UTC()}, nil
+	}
+	return Time{}, fmt.Errorf("api: cannot parse %q as a timestamp", s)
+}
+
+// String returns the time in TimeFormat, or "" for the zero time.
+func (t Time) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(TimeFormat)
+}
+
+// MarshalJSON encodes the time as a TimeFormat string, or null for the zero
+// time.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(TimeFormat))
+}
+
+// UnmarshalJSON decodes a timestamp string, a Unix timestamp number or null.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*t = Time{}
+		return nil
+	}
+	if len(data) > 0 && data[0] != '"' {
+		secs, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return fmt.Errorf("api: cannot parse %s as a timestamp", data)
+		}
+		whole := int64(secs)
+		*t = Time{Time: time.Unix(whole, int64((secs-float64(whole))*1e9)).UTC()}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseTime(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalText encodes the time as a TimeFormat string, or "" for the zero
+// time.
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes a timestamp in any of the layouts ParseTime accepts.
+func (t *Time) UnmarshalText(text []byte) error {
+	parsed, err := ParseTime(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
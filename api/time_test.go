@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	tests := map[string]struct {
+		input string
+		want  time.Time
+	}{
+		"laravel":        {`"2024-05-06T07:08:09.000000Z"`, want},
+		"rfc3339":        {`"2024-05-06T07:08:09Z"`, want},
+		"rfc3339 offset": {`"2024-05-06T09:08:09+02:00"`, want},
+		"nanoseconds":    {`"2024-05-06T07:08:09.000000001Z"`, want.Add(time.Nanosecond)},
+		"database":       {`"2024-05-06 07:08:09"`, want},
+		"no zone":        {`"2024-05-06T07:08:09"`, want},
+		"date":           {`"2024-05-06"`, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)},
+		"unix number":    {`1714979289`, want},
+		"unix string":    {`"1714979289"`, want},
+		"null":           {`null`, time.Time{}},
+		"empty string":   {`""`, time.Time{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got Time
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &got))
+			assert.True(t, tt.want.Equal(got.Time), "got %s", got.Time)
+		})
+	}
+}
+
+func TestTime_UnmarshalJSON_Invalid(t *testing.T) {
+	var got Time
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &got))
+	assert.Error(t, json.Unmarshal([]byte(`true`), &got))
+}
+
+func TestTime_RoundTrip(t *testing.T) {
+	type model struct {
+		CreatedAt Time `json:"created_at"`
+		DeletedAt Time `json:"deleted_at,omitzero"`
+	}
+
+	in := []byte(`{"created_at":"2024-05-06T07:08:09.123456Z"}`)
+	var m model
+	require.NoError(t, json.Unmarshal(in, &m))
+	assert.True(t, m.DeletedAt.IsZero())
+
+	out, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(in), string(out))
+
+	out, err = json.Marshal(struct{ At Time }{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"At":null}`, string(out))
+}
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"encoding/json"
+
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
 )
@@ -36,98 +38,85 @@ type Company struct {
 	// identify it in other contexts.
 	Reference string `json:"reference"`
 	// CreatedAt is the timestamp when the company was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the company was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
 	// DeletedAt is the timestamp when the company was deleted, if applicable.
-	DeletedAt string `json:"deleted_at,omitempty"`
+	DeletedAt api.Time `json:"deleted_at,omitzero"`
 	// RestrictAccessToRelatedCompanies indicates whether access to this
 	// company is restricted to related companies.
-	RestrictAccessToRelatedCompanies int `json:"restrict_access_to_related_companies"`
+	RestrictAccessToRelatedCompanies api.FlexBool `json:"restrict_access_to_related_companies"`
 	// IsDistributor indicates whether the company is a distributor.
 	IsDistributor bool `json:"is_distributor"`
 	// HasContract indicates whether the company has an active contract.
 	HasContract bool `json:"has_contract"`
 	// Unassignable indicates whether the company is unassignable.
 	Unassignable bool `json:"unassignable"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a company, keeping unknown fields in Extra.
+func (c *Company) UnmarshalJSON(data []byte) error {
+	type company Company
+	return api.UnmarshalWithExtra(data, (*company)(c), &c.Extra)
+}
+
+// MarshalJSON encodes a company, including the fields in Extra.
+func (c Company) MarshalJSON() ([]byte, error) {
+	type company Company
+	return api.MarshalWithExtra(company(c), c.Extra)
 }
 
+// Fields of Company, for filtering, sorting and selecting with
+// CompaniesAPI.Where, SortBy and Fields.
+var (
+	CompanyID                               = api.NewField[Company]("id")
+	CompanyName                             = api.NewField[Company]("name")
+	CompanyTelephone                        = api.NewField[Company]("telephone")
+	CompanyEmail                            = api.NewField[Company]("email")
+	CompanyWebsite                          = api.NewField[Company]("website")
+	CompanySupportPhone                     = api.NewField[Company]("support_phone")
+	CompanySupportEmail                     = api.NewField[Company]("support_email")
+	CompanyCommercialPhone                  = api.NewField[Company]("commercial_phone")
+	CompanyCommercialEmail                  = api.NewField[Company]("commercial_email")
+	CompanyInvoicingPhone                   = api.NewField[Company]("invoicing_phone")
+	CompanyInvoicingEmail                   = api.NewField[Company]("invoicing_email")
+	CompanyBillableEmployees                = api.NewField[Company]("billable_employees")
+	CompanyReference                        = api.NewField[Company]("reference")
+	CompanyCreatedAt                        = api.NewField[Company]("created_at")
+	CompanyUpdatedAt                        = api.NewField[Company]("updated_at")
+	CompanyDeletedAt                        = api.NewField[Company]("deleted_at")
+	CompanyRestrictAccessToRelatedCompanies = api.NewField[Company]("restrict_access_to_related_companies")
+	CompanyIsDistributor                    = api.NewField[Company]("is_distributor")
+	CompanyHasContract                      = api.NewField[Company]("has_contract")
+	CompanyUnassignable                     = api.NewField[Company]("unassignable")
+)
+
 // CompaniesAPI is the API for the companies resource.
 type CompaniesAPI struct {
-	api.APIRequestHandler
+	Collection[Company]
 }
 
 // CompaniesAPIResponse is the response structure for the companies API.
-type CompaniesAPIResponse struct {
-	// Data contains the list of companies.
-	Data []Company `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type CompaniesAPIResponse = List[Company]
 
 // NewCompaniesAPI creates a new CompaniesAPI instance.
 func NewCompaniesAPI(c *client.Client) *CompaniesAPI {
-	return &CompaniesAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v1/companies",
-		},
-	}
-}
-
-// Get retrieves a list of companies.
-func (c *CompaniesAPI) Get() (*CompaniesAPIResponse, error) {
-	return api.Do[CompaniesAPIResponse](c.APIRequestHandler, "GET", c.BuildURL(), nil)
-}
-
-// Create creates a new company.
-func (c *CompaniesAPI) Create(data api.APIRequestPayload) (*CompanyAPIResponse, error) {
-	return api.Do[CompanyAPIResponse](c.APIRequestHandler, "POST", c.BuildURL(), data)
+	return &CompaniesAPI{Collection: *NewCollection[Company](c, c.BaseURL+"/api/v1/companies")}
 }
 
 // CompanyAPI is the API for a single company instance.
 type CompanyAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the company.
-	ID string
+	Item[Company]
 }
 
 // CompanyAPIResponse is the response structure for a single company API.
-type CompanyAPIResponse struct {
-	// Data contains the company details.
-	Data Company `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type CompanyAPIResponse = Response[Company]
 
 // NewCompanyAPI creates a new CompanyAPI instance.
-func NewCompanyAPI(client *client.Client, id string) *CompanyAPI {
-	return &CompanyAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  client,
-			BaseURL: client.BaseURL + "/api/v1/companies/" + id,
-		},
-		ID: id,
-	}
-}
-
-// Get retrieves a single company.
-func (c *CompanyAPI) Get() (*CompanyAPIResponse, error) {
-	return api.Do[CompanyAPIResponse](c.APIRequestHandler, "GET", c.BuildURL(), nil)
-}
-
-// Update updates a company.
-func (c *CompanyAPI) Update(data api.APIRequestPayload) (*CompanyAPIResponse, error) {
-	return api.Do[CompanyAPIResponse](c.APIRequestHandler, "PUT", c.BuildURL(), data)
-}
-
-// Delete deletes a company.
-func (c *CompanyAPI) Delete() (*CompanyAPIResponse, error) {
-	return api.Do[CompanyAPIResponse](c.APIRequestHandler, "DELETE", c.BuildURL(), nil)
+func NewCompanyAPI(c *client.Client, id string) *CompanyAPI {
+	return &CompanyAPI{Item: *NewItem[Company](c, c.BaseURL+"/api/v1/companies/"+id, id)}
 }
 
 // Probes retrieves the probes API.
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"encoding/json"
+
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
 )
@@ -18,7 +20,7 @@ type HackerAlertAppliance struct {
 	Hypervisor string `json:"hypervisor"`
 	// NetworkType is the type of network configuration for the hacker alert
 	// appliance (DHCP or static).
-	NetworkType string `json:"network_type"`
+	NetworkType api.NetworkType `json:"network_type"`
 	// IPv4 is the IPv4 address of the hacker alert appliance.
 	IPv4 string `json:"ipv4"`
 	// Subnet is the subnet mask for the hacker alert appliance's network
@@ -39,109 +41,97 @@ type HackerAlertAppliance struct {
 	// about the probe will be sent.
 	NotificationEmails string `json:"notification_emails"`
 	// Status is the current status of the probe (e.g., online, offline).
-	Status string `json:"status"`
+	Status api.ProbeStatus `json:"status"`
 	// CurrentIPv4Address is the current IPv4 address of the probe, if it has
 	// been assigned one.
 	CurrentIPv4Address string `json:"current_ipv4_address"`
 	// CPUCores is the number of CPU cores allocated to the probe.
-	CPUCores int `json:"cpu_cores"`
+	CPUCores api.FlexInt `json:"cpu_cores"`
 	// Memory is the amount of memory allocated to the probe, in MB.
-	Memory string `json:"memory"`
+	Memory api.FlexString `json:"memory"`
 	// MemoryBytes is the amount of memory allocated to the probe, in bytes.
 	MemoryBytes int64 `json:"memory_bytes"`
 	// Reference is a reference string for the probe, which can be used to
 	// identify it in other contexts.
 	Reference string `json:"reference"`
 	// CreatedAt is the timestamp when the probe was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the probe was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
 	// ActiveContract is the number of active contracts associated with the
 	// probe.
 	ActiveContract int `json:"activeContract"`
-	// Company is the company that owns the probe.
-	Company struct {
-		Data Company `json:"data"`
-	} `json:"company,omitempty"`
+	// Company is the company that owns the hacker alert appliance, included
+	// via ?with=company.
+	Company Relation[Company] `json:"company,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-// HackerAlertAppliancesAPI is the API for the hacker alert appliances
-// resource.
-type HackerAlertAppliancesAPI struct {
-	api.APIRequestHandler
+// UnmarshalJSON decodes a hacker alert appliance, keeping unknown fields in Extra.
+func (h *HackerAlertAppliance) UnmarshalJSON(data []byte) error {
+	type hackerAlertAppliance HackerAlertAppliance
+	return api.UnmarshalWithExtra(data, (*hackerAlertAppliance)(h), &h.Extra)
 }
 
-// HackerAlertAppliancesAPIResponse is the response structure for the
-// hacker alert appliances API.
-type HackerAlertAppliancesAPIResponse struct {
-	// Data contains the list of hacker alert appliances.
-	Data []HackerAlertAppliance `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
+// MarshalJSON encodes a hacker alert appliance, including the fields in Extra.
+func (h HackerAlertAppliance) MarshalJSON() ([]byte, error) {
+	type hackerAlertAppliance HackerAlertAppliance
+	return api.MarshalWithExtra(hackerAlertAppliance(h), h.Extra)
 }
 
+// Fields of HackerAlertAppliance, for filtering, sorting and selecting with
+// HackerAlertAppliancesAPI.Where, SortBy and Fields.
+var (
+	HackerAlertApplianceID                 = api.NewField[HackerAlertAppliance]("id")
+	HackerAlertApplianceName               = api.NewField[HackerAlertAppliance]("name")
+	HackerAlertApplianceDescription        = api.NewField[HackerAlertAppliance]("description")
+	HackerAlertApplianceHypervisor         = api.NewField[HackerAlertAppliance]("hypervisor")
+	HackerAlertApplianceNetworkType        = api.NewField[HackerAlertAppliance]("network_type")
+	HackerAlertApplianceIPv4               = api.NewField[HackerAlertAppliance]("ipv4")
+	HackerAlertApplianceSubnet             = api.NewField[HackerAlertAppliance]("subnet")
+	HackerAlertApplianceGateway            = api.NewField[HackerAlertAppliance]("gateway")
+	HackerAlertApplianceDNS1               = api.NewField[HackerAlertAppliance]("dns1")
+	HackerAlertApplianceDNS2               = api.NewField[HackerAlertAppliance]("dns2")
+	HackerAlertApplianceDNS3               = api.NewField[HackerAlertAppliance]("dns3")
+	HackerAlertApplianceImageLocation      = api.NewField[HackerAlertAppliance]("image_location")
+	HackerAlertApplianceNotificationEmails = api.NewField[HackerAlertAppliance]("notification_emails")
+	HackerAlertApplianceStatus             = api.NewField[HackerAlertAppliance]("status")
+	HackerAlertApplianceCurrentIPv4Address = api.NewField[HackerAlertAppliance]("current_ipv4_address")
+	HackerAlertApplianceCPUCores           = api.NewField[HackerAlertAppliance]("cpu_cores")
+	HackerAlertApplianceMemory             = api.NewField[HackerAlertAppliance]("memory")
+	HackerAlertApplianceMemoryBytes        = api.NewField[HackerAlertAppliance]("memory_bytes")
+	HackerAlertApplianceReference          = api.NewField[HackerAlertAppliance]("reference")
+	HackerAlertApplianceCreatedAt          = api.NewField[HackerAlertAppliance]("created_at")
+	HackerAlertApplianceUpdatedAt          = api.NewField[HackerAlertAppliance]("updated_at")
+	HackerAlertApplianceActiveContract     = api.NewField[HackerAlertAppliance]("activeContract")
+)
+
 // HackerAlertAppliancesAPI is the API for the hacker alert appliances
-func NewHackerAlertAppliancesAPI(client *client.Client) *HackerAlertAppliancesAPI {
-	return &HackerAlertAppliancesAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  client,
-			BaseURL: client.BaseURL + "/api/v1/hacker-alert-appliances",
-		},
-	}
+// resource.
+type HackerAlertAppliancesAPI struct {
+	Collection[HackerAlertAppliance]
 }
 
-// Get retrieves a list of hacker alert appliances.
-func (h *HackerAlertAppliancesAPI) Get() (*HackerAlertAppliancesAPIResponse, error) {
-	return api.Do[HackerAlertAppliancesAPIResponse](h.APIRequestHandler, "GET", h.BuildURL(), nil)
-}
+// HackerAlertAppliancesAPIResponse is the response structure for the
+// hacker alert appliances API.
+type HackerAlertAppliancesAPIResponse = List[HackerAlertAppliance]
 
-// Create creates a new hacker alert appliance.
-func (h *HackerAlertAppliancesAPI) Create(data api.APIRequestPayload) (*HackerAlertAppliancesAPIResponse, error) {
-	return api.Do[HackerAlertAppliancesAPIResponse](h.APIRequestHandler, "POST", h.BuildURL(), data)
+// NewHackerAlertAppliancesAPI creates a new HackerAlertAppliancesAPI instance.
+func NewHackerAlertAppliancesAPI(c *client.Client) *HackerAlertAppliancesAPI {
+	return &HackerAlertAppliancesAPI{Collection: *NewCollection[HackerAlertAppliance](c, c.BaseURL+"/api/v1/hacker-alert-appliances")}
 }
 
 // HackerAlertApplianceAPI is the API for a single hacker alert appliance.
 type HackerAlertApplianceAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the hacker alert appliance.
-	ID string
+	Item[HackerAlertAppliance]
 }
 
 // HackerAlertApplianceAPIResponse is the response structure for a single
 // hacker alert appliance API.
-type HackerAlertApplianceAPIResponse struct {
-	// Data contains the hacker alert appliance instance details.
-	Data HackerAlertAppliance `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type HackerAlertApplianceAPIResponse = Response[HackerAlertAppliance]
 
 // NewHackerAlertApplianceAPI creates a new HackerAlertApplianceAPI instance.
-func NewHackerAlertApplianceAPI(client *client.Client, id string) *HackerAlertApplianceAPI {
-	return &HackerAlertApplianceAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  client,
-			BaseURL: client.BaseURL + "/api/v1/hacker-alert-appliances/" + id,
-		},
-		ID: id,
-	}
-}
-
-// Get retrieves a single hacker alert appliance.
-func (h *HackerAlertApplianceAPI) Get() (*HackerAlertApplianceAPIResponse, error) {
-	return api.Do[HackerAlertApplianceAPIResponse](h.APIRequestHandler, "GET", h.BuildURL(), nil)
-}
-
-// Update updates a hacker alert appliance.
-func (h *HackerAlertApplianceAPI) Update(data api.APIRequestPayload) (*HackerAlertApplianceAPIResponse, error) {
-	return api.Do[HackerAlertApplianceAPIResponse](h.APIRequestHandler, "PUT", h.BuildURL(), data)
-}
-
-// Delete deletes a hacker alert appliance.
-func (h *HackerAlertApplianceAPI) Delete() (*HackerAlertApplianceAPIResponse, error) {
-	return api.Do[HackerAlertApplianceAPIResponse](h.APIRequestHandler, "DELETE", h.BuildURL(), nil)
+func NewHackerAlertApplianceAPI(c *client.Client, id string) *HackerAlertApplianceAPI {
+	return &HackerAlertApplianceAPI{Item: *NewItem[HackerAlertAppliance](c, c.BaseURL+"/api/v1/hacker-alert-appliances/"+id, id)}
 }
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"encoding/json"
+
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
 )
@@ -17,7 +19,7 @@ type Probe struct {
 	Hypervisor string `json:"hypervisor"`
 	// NetworkType is the type of network configuration for the probe (DHCP or
 	// static).
-	NetworkType string `json:"network_type"`
+	NetworkType api.NetworkType `json:"network_type"`
 	// IPv4 is the IPv4 address of the probe.
 	IPv4 string `json:"ipv4"`
 	// Subnet is the subnet mask for the probe's network configuration.
@@ -37,23 +39,23 @@ type Probe struct {
 	// about the probe will be sent.
 	NotificationEmails string `json:"notification_emails"`
 	// Status is the current status of the probe (e.g., online, offline).
-	Status string `json:"status"`
+	Status api.ProbeStatus `json:"status"`
 	// CurrentIPv4Address is the current IPv4 address of the probe, if it has
 	// been assigned one.
 	CurrentIPv4Address string `json:"current_ipv4_address"`
 	// CPUCores is the number of CPU cores allocated to the probe.
-	CPUCores int `json:"cpu_cores"`
+	CPUCores api.FlexInt `json:"cpu_cores"`
 	// Memory is the amount of memory allocated to the probe, in MB.
-	Memory string `json:"memory"`
+	Memory api.FlexString `json:"memory"`
 	// MemoryBytes is the amount of memory allocated to the probe, in bytes.
 	MemoryBytes int64 `json:"memory_bytes"`
 	// Reference is a reference string for the probe, which can be used to
 	// identify it in other contexts.
 	Reference string `json:"reference"`
 	// CreatedAt is the timestamp when the probe was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the probe was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
 	// ActiveContract is the number of active contracts associated with the
 	// probe.
 	ActiveContract int `json:"activeContract"`
@@ -75,90 +77,97 @@ type Probe struct {
 	// PercentageOfScheduledTime is the percentage of scheduled time for the
 	// probe.
 	PercentageOfScheduledTime float64 `json:"percentage_of_scheduled_time"`
-	// Company is the company that owns the probe.
-	Company struct {
-		Data Company `json:"data,omitempty"`
-	} `json:"company,omitempty"`
-	// ScannerPlatform is the scanner platform associated with the probe.
-	ScannerPlatform struct {
-		Data ScannerPlatform `json:"data,omitempty"`
-	} `json:"scannerplatform,omitempty"`
+	// Company is the company that owns the probe, included via
+	// ?with=company.
+	Company Relation[Company] `json:"company,omitzero"`
+	// ScannerPlatform is the scanner platform associated with the probe,
+	// included via ?with=scannerplatform.
+	ScannerPlatform Relation[ScannerPlatform] `json:"scannerplatform,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a probe, keeping unknown fields in Extra.
+func (p *Probe) UnmarshalJSON(data []byte) error {
+	type probe Probe
+	return api.UnmarshalWithExtra(data, (*probe)(p), &p.Extra)
+}
+
+// MarshalJSON encodes a probe, including the fields in Extra.
+func (p Probe) MarshalJSON() ([]byte, error) {
+	type probe Probe
+	return api.MarshalWithExtra(probe(p), p.Extra)
 }
 
+// Fields of Probe, for filtering, sorting and selecting with
+// ProbesAPI.Where, SortBy and Fields.
+var (
+	ProbeID                          = api.NewField[Probe]("id")
+	ProbeName                        = api.NewField[Probe]("name")
+	ProbeDescription                 = api.NewField[Probe]("description")
+	ProbeHypervisor                  = api.NewField[Probe]("hypervisor")
+	ProbeNetworkType                 = api.NewField[Probe]("network_type")
+	ProbeIPv4                        = api.NewField[Probe]("ipv4")
+	ProbeSubnet                      = api.NewField[Probe]("subnet")
+	ProbeGateway                     = api.NewField[Probe]("gateway")
+	ProbeDNS1                        = api.NewField[Probe]("dns1")
+	ProbeDNS2                        = api.NewField[Probe]("dns2")
+	ProbeDNS3                        = api.NewField[Probe]("dns3")
+	ProbeImageLocation               = api.NewField[Probe]("image_location")
+	ProbeNotificationEmails          = api.NewField[Probe]("notification_emails")
+	ProbeStatus                      = api.NewField[Probe]("status")
+	ProbeCurrentIPv4Address          = api.NewField[Probe]("current_ipv4_address")
+	ProbeCPUCores                    = api.NewField[Probe]("cpu_cores")
+	ProbeMemory                      = api.NewField[Probe]("memory")
+	ProbeMemoryBytes                 = api.NewField[Probe]("memory_bytes")
+	ProbeReference                   = api.NewField[Probe]("reference")
+	ProbeCreatedAt                   = api.NewField[Probe]("created_at")
+	ProbeUpdatedAt                   = api.NewField[Probe]("updated_at")
+	ProbeActiveContract              = api.NewField[Probe]("activeContract")
+	ProbeActiveScanObjects           = api.NewField[Probe]("activeScanObjects")
+	ProbeNumberOfScanJobs            = api.NewField[Probe]("numberOfScanJobs")
+	ProbeNumberOfCompletedScanJobs   = api.NewField[Probe]("numberOfCompletedScanJobs")
+	ProbeNumberOfUncompletedScanJobs = api.NewField[Probe]("numberOfUncompletedScanJobs")
+	ProbeNumberOfFailedScanJobs      = api.NewField[Probe]("numberOfFailedScanJobs")
+	ProbePercentageOfScheduledTime   = api.NewField[Probe]("percentage_of_scheduled_time")
+)
+
 // ProbesAPI is the API for the probes resource.
 type ProbesAPI struct {
-	api.APIRequestHandler
+	Collection[Probe]
 }
 
 // ProbesAPIResponse is the response structure for the probes API.
-type ProbesAPIResponse struct {
-	// Data contains the list of probes.
-	Data []Probe `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type ProbesAPIResponse = List[Probe]
 
 // NewProbesAPI creates a new ProbesAPI instance.
 func NewProbesAPI(c *client.Client) *ProbesAPI {
-	return &ProbesAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v1/probes",
-		},
-	}
-}
-
-// Get retrieves a list of probes.
-func (p *ProbesAPI) Get() (*ProbesAPIResponse, error) {
-	return api.Do[ProbesAPIResponse](p.APIRequestHandler, "GET", p.BuildURL(), nil)
-}
-
-// Create creates a new probe.
-func (p *ProbesAPI) Create(data api.APIRequestPayload) (*ProbeAPIResponse, error) {
-	return api.Do[ProbeAPIResponse](p.APIRequestHandler, "POST", p.BuildURL(), data)
+	return &ProbesAPI{Collection: *NewCollection[Probe](c, c.BaseURL+"/api/v1/probes")}
 }
 
 // ProbeAPI is the API for a single probe instance.
 type ProbeAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the probe instance.
-	ID string
+	Item[Probe]
 }
 
 // ProbeAPIResponse is the response structure for a single probe API.
-type ProbeAPIResponse struct {
-	// Data contains the probe instance details.
-	Data Probe `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type ProbeAPIResponse = Response[Probe]
 
-// NewProbesInstanceV1 creates a new ProbeInstanceV1 instance.
+// NewProbeAPI creates a new ProbeAPI instance.
 func NewProbeAPI(c *client.Client, id string) *ProbeAPI {
-	return &ProbeAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v1/probes/" + id,
-		},
-		ID: id,
-	}
-}
-
-// Get retrieves a single probe by ID.
-func (p *ProbeAPI) Get() (*ProbeAPIResponse, error) {
-	return api.Do[ProbeAPIResponse](p.APIRequestHandler, "GET", p.BuildURL(), nil)
+	return &ProbeAPI{Item: *NewItem[Probe](c, c.BaseURL+"/api/v1/probes/"+id, id)}
 }
 
-// Update updates a probe.
-func (p *ProbeAPI) Update(data api.APIRequestPayload) (*ProbeAPIResponse, error) {
-	return api.Do[ProbeAPIResponse](p.APIRequestHandler, "PUT", p.BuildURL(), data)
+// ScanObjects retrieves the scan objects of a probe.
+func (p *ProbeAPI) ScanObjects() *ScanObjectsAPI {
+	scanObjectsAPI := NewScanObjectsAPI(p.Client)
+	scanObjectsAPI.BaseURL = p.BaseURL + "/scanobjects"
+	return scanObjectsAPI
 }
 
-// Delete deletes a probe.
-func (p *ProbeAPI) Delete() (*ProbeAPIResponse, error) {
-	return api.Do[ProbeAPIResponse](p.APIRequestHandler, "DELETE", p.BuildURL(), nil)
+// Schedules retrieves the schedules of a probe.
+func (p *ProbeAPI) Schedules() *SchedulesAPI {
+	schedulesAPI := NewSchedulesAPI(p.Client)
+	schedulesAPI.BaseURL = p.BaseURL + "/schedules"
+	return schedulesAPI
 }
@@ -0,0 +1,74 @@
+package v1
+
+// Relation is a related resource included with ?with=, which v1 wraps in a
+// {"data": ...} object. A relation that was not included, or was included
+// with a null data, is not loaded and encodes to nothing when the field is
+// tagged omitzero.
+type Relation[T any] struct {
+	// Data is the related resource, or nil if the relation is not loaded.
+	Data *T `json:"data"`
+}
+
+// NewRelation returns a loaded relation to v.
+func NewRelation[T any](v T) Relation[T] {
+	return Relation[T]{Data: &v}
+}
+
+// Loaded reports whether the relation was included in the response.
+func (r Relation[T]) Loaded() bool {
+	return r.Data != nil
+}
+
+// Get returns the related resource and whether the relation is loaded.
+func (r Relation[T]) Get() (T, bool) {
+	if r.Data == nil {
+		var zero T
+		return zero, false
+	}
+	return *r.Data, true
+}
+
+// IsZero reports whether the relation is not loaded, so that omitzero
+// leaves it out.
+func (r Relation[T]) IsZero() bool {
+	return r.Data == nil
+}
+
+// RelationList is a list of related resources included with ?with=, which
+// v1 wraps in a {"data": [...]} object. It is loaded when the relation was
+// included, even if it holds no resources, in which case Data is empty but
+// not nil.
+type RelationList[T any] struct {
+	// Data contains the related resources, or is nil if the relation is not
+	// loaded.
+	Data []T `json:"data"`
+}
+
+// NewRelationList returns a loaded relation to items.
+func NewRelationList[T any](items ...T) RelationList[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return RelationList[T]{Data: items}
+}
+
+// Loaded reports whether the relation was included in the response.
+func (r RelationList[T]) Loaded() bool {
+	return r.Data != nil
+}
+
+// Items returns the related resources, or nil if the relation is not loaded.
+func (r RelationList[T]) Items() []T {
+	return r.Data
+}
+
+// Len returns the number of related resources.
+func (r RelationList[T]) Len() int {
+	return len(r.Data)
+}
+
+// IsZero reports whether the relation is not loaded, so that omitzero
+// leaves it out.
+func (r RelationList[T]) IsZero() bool {
+	return r.Data == nil
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelation_JSON(t *testing.T) {
+	var probe Probe
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "p1",
+		"company": {"data": {"id": "c1", "name": "ACME"}},
+		"scannerplatform": {"data": null}
+	}`), &probe))
+
+	company, ok := probe.Company.Get()
+	require.True(t, ok)
+	assert.Equal(t, "ACME", company.Name)
+	assert.False(t, probe.ScannerPlatform.Loaded())
+
+	out, err := json.Marshal(probe)
+	require.NoError(t, err)
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(out, &raw))
+	assert.Contains(t, raw, "company")
+	assert.NotContains(t, raw, "scannerplatform")
+
+	var decoded Probe
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, probe.Company, decoded.Company)
+}
+
+func TestRelationList_JSON(t *testing.T) {
+	var platform ScannerPlatform
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "s1", "scanobjects": {"data": []}}`), &platform))
+	assert.True(t, platform.ScanObjects.Loaded())
+	assert.Zero(t, platform.ScanObjects.Len())
+
+	platform = ScannerPlatform{ScanObjects: NewRelationList(ScanObject{ID: "o1"}, ScanObject{ID: "o2"})}
+	out, err := json.Marshal(platform)
+	require.NoError(t, err)
+	var decoded ScannerPlatform
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	require.Equal(t, 2, decoded.ScanObjects.Len())
+	assert.Equal(t, "o2", decoded.ScanObjects.Items()[1].ID)
+
+	assert.False(t, ScannerPlatform{}.ScanObjects.Loaded())
+	assert.Nil(t, ScannerPlatform{}.ScanObjects.Items())
+}
+
+func TestRelation_LiteralIsLoaded(t *testing.T) {
+	probe := Probe{ID: "p1", Company: Relation[Company]{Data: &Company{ID: "c1"}}}
+	assert.True(t, probe.Company.Loaded())
+
+	out, err := json.Marshal(probe)
+	require.NoError(t, err)
+	var decoded Probe
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	company, ok := decoded.Company.Get()
+	require.True(t, ok)
+	assert.Equal(t, "c1", company.ID)
+}
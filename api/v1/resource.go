@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"github.com/guardian360/go-lighthouse/api"
+	"github.com/guardian360/go-lighthouse/client"
+)
+
+// List is a list of resources as returned by v1 collection endpoints. Links
+// and Meta are only set when the listing is paginated.
+type List[T any] struct {
+	// Data contains the resources.
+	Data []T `json:"data"`
+	// Links contains pagination links, if the listing is paginated.
+	Links api.PageLinks `json:"links,omitzero"`
+	// Meta contains pagination metadata, if the listing is paginated.
+	Meta api.PageMeta `json:"meta,omitzero"`
+	// Message is a message returned by the API.
+	Message string `json:"message"`
+	// Success indicates whether the API call was successful.
+	Success bool `json:"success"`
+}
+
+// Items returns the resources in the list.
+func (l List[T]) Items() []T {
+	return l.Data
+}
+
+// LastPage returns the number of the last page, or 0 if the listing is not
+// paginated.
+func (l List[T]) LastPage() int {
+	return l.Meta.LastPage
+}
+
+// Response is the v1 response for a single resource.
+type Response[T any] struct {
+	// Data contains the resource.
+	Data T `json:"data"`
+	// Message is a message returned by the API.
+	Message string `json:"message"`
+	// Success indicates whether the API call was successful.
+	Success bool `json:"success"`
+}
+
+// Value returns the resource.
+func (r Response[T]) Value() T {
+	return r.Data
+}
+
+// Collection is the v1 API for a collection of resources of type T, such as
+// /api/v1/probes. It has the methods of api.Collection, answering with the v1
+// envelopes. If the API does not paginate a listing, All yields the resources
+// of the single response.
+type Collection[T any] = api.CollectionOf[T, List[T], Response[T]]
+
+// NewCollection creates a Collection for the resources at url.
+func NewCollection[T any](c *client.Client, url string) *Collection[T] {
+	return api.NewCollectionOf[T, List[T], Response[T]](c, url)
+}
+
+// Item is the v1 API for a single resource of type T, such as
+// /api/v1/probes/{id}. It has the methods of api.Item, answering with the v1
+// envelope.
+type Item[T any] = api.ItemOf[T, Response[T]]
+
+// NewItem creates an Item for the resource with the given ID at url.
+func NewItem[T any](c *client.Client, url, id string) *Item[T] {
+	return api.NewItemOf[T, Response[T]](c, url, id)
+}
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"encoding/json"
+
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
 )
@@ -16,111 +18,85 @@ type ScanObject struct {
 	// Description is a description of the scan object.
 	Description string `json:"description"`
 	// Type is the type of the scan object (e.g., URL, IP address).
-	Type string `json:"type"`
+	Type api.ScanObjectType `json:"type"`
 	// Port is the port number associated with the scan object, if applicable.
-	Port int `json:"port"`
-	// SSL indicates whether SSL is enabled for the scan object (0 for false, 1
-	// for true).
-	SSL int `json:"ssl"`
-	// Enabled indicates whether the scan object is enabled (true or false).
-	Enabled bool `json:"enabled"`
+	Port api.FlexInt `json:"port"`
+	// SSL indicates whether SSL is enabled for the scan object. The API sends
+	// 0 or 1.
+	SSL api.FlexBool `json:"ssl"`
+	// Enabled indicates whether the scan object is enabled.
+	Enabled api.FlexBool `json:"enabled"`
 	// Reference is an optional reference string for the scan object, which can
 	// be used to identify it in other contexts.
 	Reference string `json:"reference"`
 	// CreatedAt is the timestamp when the scan object was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the scan object was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
 	// DeletedAt is the timestamp when the scan object was deleted, if
 	// applicable.
-	DeletedAt string `json:"deleted_at,omitempty"`
-	// "company": {
-	// Company represents the company associated with the scan object.
-	Company struct {
-		Data Company `json:"data"`
-	} `json:"company,omitempty"`
-	// "scannerplatform": {
-	// ScannerPlatform represents the scanner platform associated with the scan
-	// object.
-	ScannerPlatform struct {
-		Data ScannerPlatform `json:"data,omitempty"`
-	} `json:"scannerplatform,omitempty"`
+	DeletedAt api.Time `json:"deleted_at,omitzero"`
+	// Company is the company associated with the scan object, included via
+	// ?with=company.
+	Company Relation[Company] `json:"company,omitzero"`
+	// ScannerPlatform is the scanner platform associated with the scan
+	// object, included via ?with=scannerplatform.
+	ScannerPlatform Relation[ScannerPlatform] `json:"scannerplatform,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a scan object, keeping unknown fields in Extra.
+func (s *ScanObject) UnmarshalJSON(data []byte) error {
+	type scanObject ScanObject
+	return api.UnmarshalWithExtra(data, (*scanObject)(s), &s.Extra)
+}
+
+// MarshalJSON encodes a scan object, including the fields in Extra.
+func (s ScanObject) MarshalJSON() ([]byte, error) {
+	type scanObject ScanObject
+	return api.MarshalWithExtra(scanObject(s), s.Extra)
 }
 
+// Fields of ScanObject, for filtering, sorting and selecting with
+// ScanObjectsAPI.Where, SortBy and Fields.
+var (
+	ScanObjectID          = api.NewField[ScanObject]("id")
+	ScanObjectName        = api.NewField[ScanObject]("name")
+	ScanObjectValue       = api.NewField[ScanObject]("value")
+	ScanObjectDescription = api.NewField[ScanObject]("description")
+	ScanObjectType        = api.NewField[ScanObject]("type")
+	ScanObjectPort        = api.NewField[ScanObject]("port")
+	ScanObjectSSL         = api.NewField[ScanObject]("ssl")
+	ScanObjectEnabled     = api.NewField[ScanObject]("enabled")
+	ScanObjectReference   = api.NewField[ScanObject]("reference")
+	ScanObjectCreatedAt   = api.NewField[ScanObject]("created_at")
+	ScanObjectUpdatedAt   = api.NewField[ScanObject]("updated_at")
+	ScanObjectDeletedAt   = api.NewField[ScanObject]("deleted_at")
+)
+
 // ScanObjectsAPI is the API for the scan objects resource.
 type ScanObjectsAPI struct {
-	api.APIRequestHandler
+	Collection[ScanObject]
 }
 
 // ScanObjectsAPIResponse represents the response from the scan objects API.
-type ScanObjectsAPIResponse struct {
-	// Data contains the list of scan objects.
-	Data []ScanObject `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type ScanObjectsAPIResponse = List[ScanObject]
 
 // NewScanObjectsAPI creates a new ScanObjectsAPI instance.
 func NewScanObjectsAPI(c *client.Client) *ScanObjectsAPI {
-	return &ScanObjectsAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v1/scanobjects",
-		},
-	}
-}
-
-// Get retrieves a list of scan objects.
-func (s *ScanObjectsAPI) Get() (*ScanObjectsAPIResponse, error) {
-	return api.Do[ScanObjectsAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Create creates a new scan object.
-func (s *ScanObjectsAPI) Create(data api.APIRequestPayload) (*ScanObjectAPIResponse, error) {
-	return api.Do[ScanObjectAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), data)
+	return &ScanObjectsAPI{Collection: *NewCollection[ScanObject](c, c.BaseURL+"/api/v1/scanobjects")}
 }
 
 // ScanObjectAPI is the API for a single scan object instance.
 type ScanObjectAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the scan object instance.
-	ID string
+	Item[ScanObject]
 }
 
 // ScanObjectAPIResponse represents the response from a single scan object API.
-type ScanObjectAPIResponse struct {
-	// Data contains the scan object instance details.
-	Data ScanObject `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type ScanObjectAPIResponse = Response[ScanObject]
 
 // NewScanObjectAPI creates a new ScanObjectAPI instance.
 func NewScanObjectAPI(c *client.Client, id string) *ScanObjectAPI {
-	return &ScanObjectAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v1/scanobjects/" + id,
-		},
-		ID: id,
-	}
-}
-
-// Get retrieves the details of a specific scan object by its ID.
-func (s *ScanObjectAPI) Get() (*ScanObjectAPIResponse, error) {
-	return api.Do[ScanObjectAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Update updates the details of a specific scan object by its ID.
-func (s *ScanObjectAPI) Update(data api.APIRequestPayload) (*ScanObjectAPIResponse, error) {
-	return api.Do[ScanObjectAPIResponse](s.APIRequestHandler, "PUT", s.BuildURL(), data)
-}
-
-// Delete deletes a specific scan object by its ID.
-func (s *ScanObjectAPI) Delete() (*ScanObjectAPIResponse, error) {
-	return api.Do[ScanObjectAPIResponse](s.APIRequestHandler, "DELETE", s.BuildURL(), nil)
+	return &ScanObjectAPI{Item: *NewItem[ScanObject](c, c.BaseURL+"/api/v1/scanobjects/"+id, id)}
 }
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"encoding/json"
+
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
 )
@@ -17,88 +19,64 @@ type ScannerPlatform struct {
 	// scanner platform.
 	ScanObjectCount int `json:"scanobject_count"`
 	// ScanObjects contains the scan objects associated with the scanner
-	// platform.
-	ScanObjects struct {
-		Data []ScanObject `json:"data,omitempty"`
-	} `json:"scanobjects,omitempty"`
+	// platform, included via ?with=scanobjects.
+	ScanObjects RelationList[ScanObject] `json:"scanobjects,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a scanner platform, keeping unknown fields in Extra.
+func (s *ScannerPlatform) UnmarshalJSON(data []byte) error {
+	type scannerPlatform ScannerPlatform
+	return api.UnmarshalWithExtra(data, (*scannerPlatform)(s), &s.Extra)
+}
+
+// MarshalJSON encodes a scanner platform, including the fields in Extra.
+func (s ScannerPlatform) MarshalJSON() ([]byte, error) {
+	type scannerPlatform ScannerPlatform
+	return api.MarshalWithExtra(scannerPlatform(s), s.Extra)
 }
 
+// Fields of ScannerPlatform, for filtering, sorting and selecting with
+// ScannerPlatformsAPI.Where, SortBy and Fields.
+var (
+	ScannerPlatformID              = api.NewField[ScannerPlatform]("id")
+	ScannerPlatformDescription     = api.NewField[ScannerPlatform]("description")
+	ScannerPlatformCompanyID       = api.NewField[ScannerPlatform]("company_id")
+	ScannerPlatformScanObjectCount = api.NewField[ScannerPlatform]("scanobject_count")
+)
+
 // ScannerPlatformsAPI is the API for the scanner platforms resource.
 type ScannerPlatformsAPI struct {
-	api.APIRequestHandler
+	Collection[ScannerPlatform]
 }
 
 // ScannerPlatformsAPIResponse represents the response from the scanner
 // platforms API.
-type ScannerPlatformsAPIResponse struct {
-	// Data contains the list of scanner platforms.
-	Data []ScannerPlatform `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type ScannerPlatformsAPIResponse = List[ScannerPlatform]
 
 // NewScannerPlatformsAPI creates a new ScannerPlatformsAPI instance.
 func NewScannerPlatformsAPI(c *client.Client) *ScannerPlatformsAPI {
-	return &ScannerPlatformsAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v1/scannerplatforms",
-		},
-	}
-}
-
-// Get retrieves a list of scanner platforms.
-func (s *ScannerPlatformsAPI) Get() (*ScannerPlatformsAPIResponse, error) {
-	return api.Do[ScannerPlatformsAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Create creates a new scanner platform.
-func (s *ScannerPlatformsAPI) Create(data api.APIRequestPayload) (*ScannerPlatformAPIResponse, error) {
-	return api.Do[ScannerPlatformAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), data)
+	return &ScannerPlatformsAPI{Collection: *NewCollection[ScannerPlatform](c, c.BaseURL+"/api/v1/scannerplatforms")}
 }
 
 // ScannerPlatformAPI is the API for a single scanner platform instance.
 type ScannerPlatformAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the scanner platform instance.
-	ID string
+	Item[ScannerPlatform]
 }
 
 // ScannerPlatformAPIResponse represents the response from a single scanner
 // platform API.
-type ScannerPlatformAPIResponse struct {
-	// Data contains the scanner platform instance details.
-	Data ScannerPlatform `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type ScannerPlatformAPIResponse = Response[ScannerPlatform]
 
 // NewScannerPlatformAPI creates a new ScannerPlatformAPI instance.
 func NewScannerPlatformAPI(c *client.Client, id string) *ScannerPlatformAPI {
-	return &ScannerPlatformAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v1/scannerplatforms/" + id,
-		},
-		ID: id,
-	}
-}
-
-// Get retrieves a single scanner platform by its ID.
-func (s *ScannerPlatformAPI) Get() (*ScannerPlatformAPIResponse, error) {
-	return api.Do[ScannerPlatformAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Update updates an existing scanner platform.
-func (s *ScannerPlatformAPI) Update(data api.APIRequestPayload) (*ScannerPlatformAPIResponse, error) {
-	return api.Do[ScannerPlatformAPIResponse](s.APIRequestHandler, "PUT", s.BuildURL(), data)
+	return &ScannerPlatformAPI{Item: *NewItem[ScannerPlatform](c, c.BaseURL+"/api/v1/scannerplatforms/"+id, id)}
 }
 
-// Delete deletes a scanner platform by its ID.
-func (s *ScannerPlatformAPI) Delete() (*ScannerPlatformAPIResponse, error) {
-	return api.Do[ScannerPlatformAPIResponse](s.APIRequestHandler, "DELETE", s.BuildURL(), nil)
+// ScanObjects retrieves the scan objects of a scanner platform.
+func (s *ScannerPlatformAPI) ScanObjects() *ScanObjectsAPI {
+	scanObjectsAPI := NewScanObjectsAPI(s.Client)
+	scanObjectsAPI.BaseURL = s.BaseURL + "/scanobjects"
+	return scanObjectsAPI
 }
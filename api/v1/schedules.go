@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"encoding/json"
+
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
 )
@@ -18,82 +20,56 @@ type Schedule struct {
 	// To is the end time of the schedule in HH:MM format.
 	To string `json:"to"`
 	// Active indicates whether the schedule is currently active.
-	Active bool `json:"active"`
+	Active api.FlexBool `json:"active"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a schedule, keeping unknown fields in Extra.
+func (s *Schedule) UnmarshalJSON(data []byte) error {
+	type schedule Schedule
+	return api.UnmarshalWithExtra(data, (*schedule)(s), &s.Extra)
+}
+
+// MarshalJSON encodes a schedule, including the fields in Extra.
+func (s Schedule) MarshalJSON() ([]byte, error) {
+	type schedule Schedule
+	return api.MarshalWithExtra(schedule(s), s.Extra)
 }
 
+// Fields of Schedule, for filtering, sorting and selecting with
+// SchedulesAPI.Where, SortBy and Fields.
+var (
+	ScheduleID          = api.NewField[Schedule]("id")
+	ScheduleName        = api.NewField[Schedule]("name")
+	ScheduleDescription = api.NewField[Schedule]("description")
+	ScheduleFrom        = api.NewField[Schedule]("from")
+	ScheduleTo          = api.NewField[Schedule]("to")
+	ScheduleActive      = api.NewField[Schedule]("active")
+)
+
 // SchedulesAPI is the API for the schedules resource.
 type SchedulesAPI struct {
-	api.APIRequestHandler
+	Collection[Schedule]
 }
 
 // SchedulesAPIResponse represents the response from the schedules API.
-type SchedulesAPIResponse struct {
-	// Data contains the list of schedules.
-	Data []Schedule `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type SchedulesAPIResponse = List[Schedule]
 
 // NewSchedulesAPI creates a new SchedulesAPI instance.
 func NewSchedulesAPI(c *client.Client) *SchedulesAPI {
-	return &SchedulesAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v1/schedules",
-		},
-	}
-}
-
-// Get retrieves a list of schedules.
-func (s *SchedulesAPI) Get() (*SchedulesAPIResponse, error) {
-	return api.Do[SchedulesAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Create creates a new schedule.
-func (s *SchedulesAPI) Create(data api.APIRequestPayload) (*ScheduleAPIResponse, error) {
-	return api.Do[ScheduleAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), data)
+	return &SchedulesAPI{Collection: *NewCollection[Schedule](c, c.BaseURL+"/api/v1/schedules")}
 }
 
 // ScheduleAPI is the API for a single schedule instance.
 type ScheduleAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the schedule instance.
-	ID string
+	Item[Schedule]
 }
 
 // ScheduleAPIResponse represents the response from a single schedule API.
-type ScheduleAPIResponse struct {
-	// Data contains the schedule instance details.
-	Data Schedule `json:"data"`
-	// Message is a message returned by the API.
-	Message string `json:"message"`
-	// Success indicates whether the API call was successful.
-	Success bool `json:"success"`
-}
+type ScheduleAPIResponse = Response[Schedule]
 
 // NewScheduleAPI creates a new ScheduleAPI instance.
 func NewScheduleAPI(c *client.Client, id string) *ScheduleAPI {
-	return &ScheduleAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v1/schedules/" + id,
-		},
-	}
-}
-
-// Get retrieves a single schedule by its ID.
-func (s *ScheduleAPI) Get() (*ScheduleAPIResponse, error) {
-	return api.Do[ScheduleAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Update updates an existing schedule.
-func (s *ScheduleAPI) Update(data api.APIRequestPayload) (*ScheduleAPIResponse, error) {
-	return api.Do[ScheduleAPIResponse](s.APIRequestHandler, "PUT", s.BuildURL(), data)
-}
-
-// Delete deletes a schedule by its ID.
-func (s *ScheduleAPI) Delete() (*ScheduleAPIResponse, error) {
-	return api.Do[ScheduleAPIResponse](s.APIRequestHandler, "DELETE", s.BuildURL(), nil)
+	return &ScheduleAPI{Item: *NewItem[Schedule](c, c.BaseURL+"/api/v1/schedules/"+id, id)}
 }
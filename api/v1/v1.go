@@ -71,6 +71,16 @@ func (api *API) ScanObject(id string) *ScanObjectAPI {
 	return NewScanObjectAPI(api.Client, id)
 }
 
+// ScannerPlatforms retrieves the scanner platforms API.
+func (api *API) ScannerPlatforms() *ScannerPlatformsAPI {
+	return NewScannerPlatformsAPI(api.Client)
+}
+
+// ScannerPlatform retrieves the scanner platform API for a specific ID.
+func (api *API) ScannerPlatform(id string) *ScannerPlatformAPI {
+	return NewScannerPlatformAPI(api.Client, id)
+}
+
 // APIResponse is the response wrapper for API v1.
 type APIResponse struct {
 	Success bool        `json:"success"`
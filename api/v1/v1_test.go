@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPI_Accessors(t *testing.T) {
+	lh := New(client.New("http://example.com"))
+
+	for want, got := range map[string]string{
+		"http://example.com/api/v1/scannerplatforms":   lh.ScannerPlatforms().BuildURL(),
+		"http://example.com/api/v1/scannerplatforms/1": lh.ScannerPlatform("1").BuildURL(),
+	} {
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCollection_BuildersKeepResourceType(t *testing.T) {
+	probes := New(client.New("http://example.com")).Probes().Page(2).PerPage(10).SortBy(ProbeName.Desc())
+
+	assert.IsType(t, &ProbesAPI{}, probes)
+	assert.Equal(t, "http://example.com/api/v1/probes?page=2&per_page=10&sort=-name", probes.BuildURL())
+}
@@ -0,0 +1,798 @@
+// Code generated by buildergen. DO NOT EDIT.
+
+package v2
+
+import "github.com/guardian360/go-lighthouse/api"
+
+// Page sets the page number for pagination.
+func (r *CompaniesAPI) Page(page int) *CompaniesAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *CompaniesAPI) PerPage(perPage int) *CompaniesAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *CompaniesAPI) Scopes(scopes ...string) *CompaniesAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *CompaniesAPI) With(relationships ...string) *CompaniesAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *CompaniesAPI) Sort(sort, order string) *CompaniesAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *CompaniesAPI) Where(conditions ...api.Condition[Company]) *CompaniesAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *CompaniesAPI) SortBy(sorts ...api.Sort[Company]) *CompaniesAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *CompaniesAPI) Fields(fields ...api.Field[Company]) *CompaniesAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *CompanyAPI) With(relationships ...string) *CompanyAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *CompanyAPI) Fields(fields ...api.Field[Company]) *CompanyAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *CrawledURLAPI) With(relationships ...string) *CrawledURLAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *CrawledURLAPI) Fields(fields ...api.Field[CrawledURL]) *CrawledURLAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *CrawledURLsAPI) Page(page int) *CrawledURLsAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *CrawledURLsAPI) PerPage(perPage int) *CrawledURLsAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *CrawledURLsAPI) Scopes(scopes ...string) *CrawledURLsAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *CrawledURLsAPI) With(relationships ...string) *CrawledURLsAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *CrawledURLsAPI) Sort(sort, order string) *CrawledURLsAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *CrawledURLsAPI) Where(conditions ...api.Condition[CrawledURL]) *CrawledURLsAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *CrawledURLsAPI) SortBy(sorts ...api.Sort[CrawledURL]) *CrawledURLsAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *CrawledURLsAPI) Fields(fields ...api.Field[CrawledURL]) *CrawledURLsAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *HackerAlertAPI) With(relationships ...string) *HackerAlertAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *HackerAlertAPI) Fields(fields ...api.Field[HackerAlert]) *HackerAlertAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *HackerAlertApplianceAPI) With(relationships ...string) *HackerAlertApplianceAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *HackerAlertApplianceAPI) Fields(fields ...api.Field[HackerAlertAppliance]) *HackerAlertApplianceAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *HackerAlertAppliancesAPI) Page(page int) *HackerAlertAppliancesAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *HackerAlertAppliancesAPI) PerPage(perPage int) *HackerAlertAppliancesAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *HackerAlertAppliancesAPI) Scopes(scopes ...string) *HackerAlertAppliancesAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *HackerAlertAppliancesAPI) With(relationships ...string) *HackerAlertAppliancesAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *HackerAlertAppliancesAPI) Sort(sort, order string) *HackerAlertAppliancesAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *HackerAlertAppliancesAPI) Where(conditions ...api.Condition[HackerAlertAppliance]) *HackerAlertAppliancesAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *HackerAlertAppliancesAPI) SortBy(sorts ...api.Sort[HackerAlertAppliance]) *HackerAlertAppliancesAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *HackerAlertAppliancesAPI) Fields(fields ...api.Field[HackerAlertAppliance]) *HackerAlertAppliancesAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *HackerAlertsAPI) Page(page int) *HackerAlertsAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *HackerAlertsAPI) PerPage(perPage int) *HackerAlertsAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *HackerAlertsAPI) Scopes(scopes ...string) *HackerAlertsAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *HackerAlertsAPI) With(relationships ...string) *HackerAlertsAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *HackerAlertsAPI) Sort(sort, order string) *HackerAlertsAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *HackerAlertsAPI) Where(conditions ...api.Condition[HackerAlert]) *HackerAlertsAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *HackerAlertsAPI) SortBy(sorts ...api.Sort[HackerAlert]) *HackerAlertsAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *HackerAlertsAPI) Fields(fields ...api.Field[HackerAlert]) *HackerAlertsAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *HostDiscoveriesAPI) Page(page int) *HostDiscoveriesAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *HostDiscoveriesAPI) PerPage(perPage int) *HostDiscoveriesAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *HostDiscoveriesAPI) Scopes(scopes ...string) *HostDiscoveriesAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *HostDiscoveriesAPI) With(relationships ...string) *HostDiscoveriesAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *HostDiscoveriesAPI) Sort(sort, order string) *HostDiscoveriesAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *HostDiscoveriesAPI) Where(conditions ...api.Condition[HostDiscovery]) *HostDiscoveriesAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *HostDiscoveriesAPI) SortBy(sorts ...api.Sort[HostDiscovery]) *HostDiscoveriesAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *HostDiscoveriesAPI) Fields(fields ...api.Field[HostDiscovery]) *HostDiscoveriesAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *HostDiscoveryAPI) With(relationships ...string) *HostDiscoveryAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *HostDiscoveryAPI) Fields(fields ...api.Field[HostDiscovery]) *HostDiscoveryAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ProbeAPI) With(relationships ...string) *ProbeAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *ProbeAPI) Fields(fields ...api.Field[Probe]) *ProbeAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *ProbesAPI) Page(page int) *ProbesAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *ProbesAPI) PerPage(perPage int) *ProbesAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *ProbesAPI) Scopes(scopes ...string) *ProbesAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ProbesAPI) With(relationships ...string) *ProbesAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *ProbesAPI) Sort(sort, order string) *ProbesAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *ProbesAPI) Where(conditions ...api.Condition[Probe]) *ProbesAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *ProbesAPI) SortBy(sorts ...api.Sort[Probe]) *ProbesAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *ProbesAPI) Fields(fields ...api.Field[Probe]) *ProbesAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *RescanTargetAPI) With(relationships ...string) *RescanTargetAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *RescanTargetAPI) Fields(fields ...api.Field[RescanTarget]) *RescanTargetAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *RescanTargetsAPI) Page(page int) *RescanTargetsAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *RescanTargetsAPI) PerPage(perPage int) *RescanTargetsAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *RescanTargetsAPI) Scopes(scopes ...string) *RescanTargetsAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *RescanTargetsAPI) With(relationships ...string) *RescanTargetsAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *RescanTargetsAPI) Sort(sort, order string) *RescanTargetsAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *RescanTargetsAPI) Where(conditions ...api.Condition[RescanTarget]) *RescanTargetsAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *RescanTargetsAPI) SortBy(sorts ...api.Sort[RescanTarget]) *RescanTargetsAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *RescanTargetsAPI) Fields(fields ...api.Field[RescanTarget]) *RescanTargetsAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScanObjectAPI) With(relationships ...string) *ScanObjectAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *ScanObjectAPI) Fields(fields ...api.Field[ScanObject]) *ScanObjectAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScanObjectExclusionAPI) With(relationships ...string) *ScanObjectExclusionAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *ScanObjectExclusionAPI) Fields(fields ...api.Field[ScanObjectExclusion]) *ScanObjectExclusionAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *ScanObjectExclusionsAPI) Page(page int) *ScanObjectExclusionsAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *ScanObjectExclusionsAPI) PerPage(perPage int) *ScanObjectExclusionsAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *ScanObjectExclusionsAPI) Scopes(scopes ...string) *ScanObjectExclusionsAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScanObjectExclusionsAPI) With(relationships ...string) *ScanObjectExclusionsAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *ScanObjectExclusionsAPI) Sort(sort, order string) *ScanObjectExclusionsAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *ScanObjectExclusionsAPI) Where(conditions ...api.Condition[ScanObjectExclusion]) *ScanObjectExclusionsAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *ScanObjectExclusionsAPI) SortBy(sorts ...api.Sort[ScanObjectExclusion]) *ScanObjectExclusionsAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *ScanObjectExclusionsAPI) Fields(fields ...api.Field[ScanObjectExclusion]) *ScanObjectExclusionsAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *ScanObjectsAPI) Page(page int) *ScanObjectsAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *ScanObjectsAPI) PerPage(perPage int) *ScanObjectsAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *ScanObjectsAPI) Scopes(scopes ...string) *ScanObjectsAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScanObjectsAPI) With(relationships ...string) *ScanObjectsAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *ScanObjectsAPI) Sort(sort, order string) *ScanObjectsAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *ScanObjectsAPI) Where(conditions ...api.Condition[ScanObject]) *ScanObjectsAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *ScanObjectsAPI) SortBy(sorts ...api.Sort[ScanObject]) *ScanObjectsAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *ScanObjectsAPI) Fields(fields ...api.Field[ScanObject]) *ScanObjectsAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScanResultAPI) With(relationships ...string) *ScanResultAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *ScanResultAPI) Fields(fields ...api.Field[ScanResult]) *ScanResultAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *ScanResultsAPI) Page(page int) *ScanResultsAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *ScanResultsAPI) PerPage(perPage int) *ScanResultsAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *ScanResultsAPI) Scopes(scopes ...string) *ScanResultsAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScanResultsAPI) With(relationships ...string) *ScanResultsAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *ScanResultsAPI) Sort(sort, order string) *ScanResultsAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *ScanResultsAPI) Where(conditions ...api.Condition[ScanResult]) *ScanResultsAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *ScanResultsAPI) SortBy(sorts ...api.Sort[ScanResult]) *ScanResultsAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *ScanResultsAPI) Fields(fields ...api.Field[ScanResult]) *ScanResultsAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScanTaskAPI) With(relationships ...string) *ScanTaskAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *ScanTaskAPI) Fields(fields ...api.Field[ScanTask]) *ScanTaskAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *ScanTasksAPI) Page(page int) *ScanTasksAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *ScanTasksAPI) PerPage(perPage int) *ScanTasksAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *ScanTasksAPI) Scopes(scopes ...string) *ScanTasksAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScanTasksAPI) With(relationships ...string) *ScanTasksAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *ScanTasksAPI) Sort(sort, order string) *ScanTasksAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *ScanTasksAPI) Where(conditions ...api.Condition[ScanTask]) *ScanTasksAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *ScanTasksAPI) SortBy(sorts ...api.Sort[ScanTask]) *ScanTasksAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *ScanTasksAPI) Fields(fields ...api.Field[ScanTask]) *ScanTasksAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScannerPlatformAPI) With(relationships ...string) *ScannerPlatformAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *ScannerPlatformAPI) Fields(fields ...api.Field[ScannerPlatform]) *ScannerPlatformAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *ScannerPlatformsAPI) Page(page int) *ScannerPlatformsAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *ScannerPlatformsAPI) PerPage(perPage int) *ScannerPlatformsAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *ScannerPlatformsAPI) Scopes(scopes ...string) *ScannerPlatformsAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScannerPlatformsAPI) With(relationships ...string) *ScannerPlatformsAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *ScannerPlatformsAPI) Sort(sort, order string) *ScannerPlatformsAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *ScannerPlatformsAPI) Where(conditions ...api.Condition[ScannerPlatform]) *ScannerPlatformsAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *ScannerPlatformsAPI) SortBy(sorts ...api.Sort[ScannerPlatform]) *ScannerPlatformsAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *ScannerPlatformsAPI) Fields(fields ...api.Field[ScannerPlatform]) *ScannerPlatformsAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *ScheduleAPI) With(relationships ...string) *ScheduleAPI {
+	r.Item.With(relationships...)
+	return r
+}
+
+// Fields limits the fields included in the resource.
+func (r *ScheduleAPI) Fields(fields ...api.Field[Schedule]) *ScheduleAPI {
+	r.Item.Fields(fields...)
+	return r
+}
+
+// Page sets the page number for pagination.
+func (r *SchedulesAPI) Page(page int) *SchedulesAPI {
+	r.Collection.Page(page)
+	return r
+}
+
+// PerPage sets the number of items per page for pagination.
+func (r *SchedulesAPI) PerPage(perPage int) *SchedulesAPI {
+	r.Collection.PerPage(perPage)
+	return r
+}
+
+// Scopes sets the scopes to filter by.
+func (r *SchedulesAPI) Scopes(scopes ...string) *SchedulesAPI {
+	r.Collection.Scopes(scopes...)
+	return r
+}
+
+// With sets the relationships to include in the response.
+func (r *SchedulesAPI) With(relationships ...string) *SchedulesAPI {
+	r.Collection.With(relationships...)
+	return r
+}
+
+// Sort sets the sorting key and order.
+func (r *SchedulesAPI) Sort(sort, order string) *SchedulesAPI {
+	r.Collection.Sort(sort, order)
+	return r
+}
+
+// Where filters the collection on the given conditions.
+func (r *SchedulesAPI) Where(conditions ...api.Condition[Schedule]) *SchedulesAPI {
+	r.Collection.Where(conditions...)
+	return r
+}
+
+// SortBy sorts the collection on one or more fields, the first taking
+// precedence. It replaces any sort set with Sort.
+func (r *SchedulesAPI) SortBy(sorts ...api.Sort[Schedule]) *SchedulesAPI {
+	r.Collection.SortBy(sorts...)
+	return r
+}
+
+// Fields limits the fields included for each resource.
+func (r *SchedulesAPI) Fields(fields ...api.Field[Schedule]) *SchedulesAPI {
+	r.Collection.Fields(fields...)
+	return r
+}
@@ -1,19 +1,43 @@
 package v2
 
-// Company represents a company in the Lighthouse API.
-type Company struct {
-	// ID is the unique identifier for the company.
-	ID string `json:"id"`
-	// Name is the name of the company.
-	Name string `json:"name"`
-	// Website is the company's website URL.
-	Website string `json:"website"`
-	// Email is the company's email address.
-	Email string `json:"email"`
-	// CreatedAt is the timestamp when the company was created.
-	CreatedAt string `json:"created_at"`
-	// UpdatedAt is the timestamp when the company was last updated.
-	UpdatedAt string `json:"updated_at"`
-	// DeletedAt is the timestamp when the company was deleted, if applicable.
-	DeletedAt string `json:"deleted_at,omitempty"`
-}
\ No newline at end of file
+// Probes retrieves the probes for a company.
+func (c *CompanyAPI) Probes() *ProbesAPI {
+	probesAPI := NewProbesAPI(c.Client)
+	probesAPI.BaseURL = c.BaseURL + "/probes"
+	return probesAPI
+}
+
+// HackerAlertAppliances retrieves the hacker alert appliances for a company.
+func (c *CompanyAPI) HackerAlertAppliances() *HackerAlertAppliancesAPI {
+	hackerAlertAppliancesAPI := NewHackerAlertAppliancesAPI(c.Client)
+	hackerAlertAppliancesAPI.BaseURL = c.BaseURL + "/hacker-alert-appliances"
+	return hackerAlertAppliancesAPI
+}
+
+// ScanObjects retrieves the scan objects for a company.
+func (c *CompanyAPI) ScanObjects() *ScanObjectsAPI {
+	scanObjectsAPI := NewScanObjectsAPI(c.Client)
+	scanObjectsAPI.BaseURL = c.BaseURL + "/scanobjects"
+	return scanObjectsAPI
+}
+
+// ScannerPlatforms retrieves the scanner platforms for a company.
+func (c *CompanyAPI) ScannerPlatforms() *ScannerPlatformsAPI {
+	scannerPlatformsAPI := NewScannerPlatformsAPI(c.Client)
+	scannerPlatformsAPI.BaseURL = c.BaseURL + "/scannerplatforms"
+	return scannerPlatformsAPI
+}
+
+// Schedules retrieves the schedules for a company.
+func (c *CompanyAPI) Schedules() *SchedulesAPI {
+	schedulesAPI := NewSchedulesAPI(c.Client)
+	schedulesAPI.BaseURL = c.BaseURL + "/schedules"
+	return schedulesAPI
+}
+
+// ScanTasks retrieves the scan tasks for a company.
+func (c *CompanyAPI) ScanTasks() *ScanTasksAPI {
+	scanTasksAPI := NewScanTasksAPI(c.Client)
+	scanTasksAPI.BaseURL = c.BaseURL + "/scan-tasks"
+	return scanTasksAPI
+}
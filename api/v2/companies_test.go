@@ -0,0 +1,34 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompanyAPI_SubResources(t *testing.T) {
+	company := New(client.New("http://example.com")).Company("c1")
+
+	for want, got := range map[string]string{
+		"http://example.com/api/v2/companies/c1/probes":                  company.Probes().BuildURL(),
+		"http://example.com/api/v2/companies/c1/hacker-alert-appliances": company.HackerAlertAppliances().BuildURL(),
+		"http://example.com/api/v2/companies/c1/scanobjects":             company.ScanObjects().BuildURL(),
+		"http://example.com/api/v2/companies/c1/scannerplatforms":        company.ScannerPlatforms().BuildURL(),
+		"http://example.com/api/v2/companies/c1/schedules":               company.Schedules().BuildURL(),
+		"http://example.com/api/v2/companies/c1/scan-tasks":              company.ScanTasks().BuildURL(),
+	} {
+		assert.Equal(t, want, got)
+	}
+
+	probes := company.Probes().PerPage(5)
+	assert.IsType(t, &ProbesAPI{}, probes)
+	assert.Equal(t, "http://example.com/api/v2/companies/c1/probes?per_page=5", probes.BuildURL())
+}
+
+func TestCompanyAPI_SubResourcesIgnoreItemParams(t *testing.T) {
+	company := New(client.New("http://example.com")).Company("c1")
+	company.With("probes")
+
+	assert.Equal(t, "http://example.com/api/v2/companies/c1/schedules", company.Schedules().BuildURL())
+}
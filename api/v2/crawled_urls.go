@@ -1,8 +1,7 @@
 package v2
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
 
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
@@ -12,44 +11,49 @@ import (
 type CrawledURL struct {
 	// ID is the unique identifier for the crawled URL.
 	ID string `json:"id"`
-	// URL is the URL of the crawled URL.
-	Timestamp string `json:"timestamp"`
+	// Timestamp is the time at which the URL was crawled.
+	Timestamp api.Time `json:"timestamp"`
 	// Request is the request that was made to discover the crawled URL.
 	Request map[string]interface{} `json:"request"`
 	// Response is the response from the request made to the crawled URL.
 	Response map[string]interface{} `json:"response"`
 	// Error is the error message of the crawled URL, if applicable.
 	Error string `json:"error"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// UnmarshalJSON decodes a crawled URL, keeping unknown fields in Extra.
+func (c *CrawledURL) UnmarshalJSON(data []byte) error {
+	type crawledURL CrawledURL
+	return api.UnmarshalWithExtra(data, (*crawledURL)(c), &c.Extra)
+}
+
+// MarshalJSON encodes a crawled URL, including the fields in Extra.
+func (c CrawledURL) MarshalJSON() ([]byte, error) {
+	type crawledURL CrawledURL
+	return api.MarshalWithExtra(crawledURL(c), c.Extra)
+}
+
+// Fields of CrawledURL, for filtering, sorting and selecting with
+// CrawledURLsAPI.Where, SortBy and Fields.
+var (
+	CrawledURLID        = api.NewField[CrawledURL]("id")
+	CrawledURLTimestamp = api.NewField[CrawledURL]("timestamp")
+	CrawledURLError     = api.NewField[CrawledURL]("error")
+)
+
 // CrawledURLsAPI is the API for the crawled URLs resource.
 type CrawledURLsAPI struct {
-	api.APIRequestHandler
+	api.Collection[CrawledURL]
 }
 
 // CrawledURLsAPIResponse is the response structure for the crawled URLs API.
-type CrawledURLsAPIResponse struct {
-	// Data contains the list of scan results.
-	Data []CrawledURL `json:"data"`
-	// Links contains pagination and other links.
-	Links APIResponseLinks `json:"links,omitempty"`
-	// Meta contains metadata about the response.
-	Meta APIResponseMeta `json:"meta,omitempty"`
-}
+type CrawledURLsAPIResponse = api.List[CrawledURL]
 
 // NewCrawledURLsAPI creates a new CrawledURLsAPI instance.
 func NewCrawledURLsAPI(c *client.Client) *CrawledURLsAPI {
-	return &CrawledURLsAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/crawled-urls",
-		},
-	}
-}
-
-// Get retrieves a list of crawled URLs.
-func (h *CrawledURLsAPI) Get() (*CrawledURLsAPIResponse, error) {
-	return api.Do[CrawledURLsAPIResponse](h.APIRequestHandler, "GET", h.BuildURL(), nil)
+	return &CrawledURLsAPI{Collection: *api.NewCollection[CrawledURL](c, c.BaseURL+"/api/v2/crawled-urls")}
 }
 
 // Upsert creates or updates a crawled URL.
@@ -57,56 +61,71 @@ func (h *CrawledURLsAPI) Upsert(data api.APIRequestPayload) (*CrawledURLAPIRespo
 	return api.Do[CrawledURLAPIResponse](h.APIRequestHandler, "POST", h.BuildURL(), data)
 }
 
-// Page sets the page number for pagination.
-func (p *CrawledURLsAPI) Page(page int) *CrawledURLsAPI {
-	p.SetParam("page", fmt.Sprintf("%d", page))
-	return p
+// CrawledURLAPI is the API for a single crawled URL instance.
+type CrawledURLAPI struct {
+	api.Item[CrawledURL]
 }
 
-// PerPage sets the number of items per page for pagination.
-func (p *CrawledURLsAPI) PerPage(perPage int) *CrawledURLsAPI {
-	p.SetParam("per_page", fmt.Sprintf("%d", perPage))
-	return p
-}
+// CrawledURLAPIResponse is the response structure for a single host
+// discovery.
+type CrawledURLAPIResponse = api.Response[CrawledURL]
 
-// Scopes sets the scopes to filter by.
-func (p *CrawledURLsAPI) Scopes(scopes ...string) *CrawledURLsAPI {
-	p.SetParam("scopes", strings.Join(scopes, ","))
-	return p
+// NewCrawledURLAPI creates a new CrawledURLAPI instance.
+func NewCrawledURLAPI(c *client.Client, id string) *CrawledURLAPI {
+	return &CrawledURLAPI{Item: *api.NewItem[CrawledURL](c, c.BaseURL+"/api/v2/crawled-urls/"+id, id)}
 }
 
-// Sort sets the sorting key and order.
-func (p *CrawledURLsAPI) Sort(sort, order string) *CrawledURLsAPI {
-	p.SetParam("sort", sort+","+order)
-	return p
+// URL returns the crawled URL, as recorded in the request.
+func (c CrawledURL) URL() string {
+	return requestURL(c.Request)
 }
 
-// CrawledURLAPI is the API for a single crawled URL instance.
-type CrawledURLAPI struct {
-	api.APIRequestHandler
+// CrawledURLSummary is a lightweight projection of CrawledURL without the
+// response, for large listings. The full record can be fetched with
+// CrawledURL(id).Get().
+type CrawledURLSummary struct {
 	// ID is the unique identifier for the crawled URL.
-	ID string
+	ID string `json:"id"`
+	// Timestamp is the time at which the URL was crawled.
+	Timestamp api.Time `json:"timestamp"`
+	// Request is the request that was made to discover the crawled URL.
+	Request map[string]interface{} `json:"request"`
+	// Error is the error message of the crawled URL, if applicable.
+	Error string `json:"error"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-// CrawledURLAPIResponse is the response structure for a single host
-// discovery.
-type CrawledURLAPIResponse struct {
-	// Data contains the crawled URL details.
-	Data CrawledURL `json:"data"`
+// URL returns the crawled URL, as recorded in the request.
+func (c CrawledURLSummary) URL() string {
+	return requestURL(c.Request)
 }
 
-// NewCrawledURLAPI creates a new CrawledURLAPI instance.
-func NewCrawledURLAPI(c *client.Client, id string) *CrawledURLAPI {
-	return &CrawledURLAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/crawled-urls/" + id,
-		},
-		ID: id,
-	}
+// requestURL returns the url of a crawl request, or "" if it has none.
+func requestURL(request map[string]interface{}) string {
+	url, _ := request["url"].(string)
+	return url
+}
+
+// CrawledURLSummariesResponse is the response structure for a page of
+// crawled URL summaries.
+type CrawledURLSummariesResponse = api.List[CrawledURLSummary]
+
+// UnmarshalJSON decodes a crawled URL summary, keeping unknown fields in
+// Extra.
+func (c *CrawledURLSummary) UnmarshalJSON(data []byte) error {
+	type crawledURLSummary CrawledURLSummary
+	return api.UnmarshalWithExtra(data, (*crawledURLSummary)(c), &c.Extra)
+}
+
+// MarshalJSON encodes a crawled URL summary, including the fields in Extra.
+func (c CrawledURLSummary) MarshalJSON() ([]byte, error) {
+	type crawledURLSummary CrawledURLSummary
+	return api.MarshalWithExtra(crawledURLSummary(c), c.Extra)
 }
 
-// Get retrieves a single crawled URL by ID.
-func (h *CrawledURLAPI) Get() (*CrawledURLAPIResponse, error) {
-	return api.Do[CrawledURLAPIResponse](h.APIRequestHandler, "GET", h.BuildURL(), nil)
+// Summaries retrieves a page of crawled URL summaries, requesting only the
+// fields CrawledURLSummary declares.
+func (h *CrawledURLsAPI) Summaries() (*CrawledURLSummariesResponse, error) {
+	return api.GetAs[CrawledURLSummary](h)
 }
@@ -0,0 +1,161 @@
+package v2
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/guardian360/go-lighthouse/api"
+)
+
+// target is a host, URL or port to check against exclusions, split into the
+// parts the exclusion types compare.
+type target struct {
+	host string
+	ip   net.IP
+	port int
+	url  *url.URL
+}
+
+// parseTarget parses a URL such as https://example.com/admin, a host with an
+// optional port such as 10.0.0.1:8443, or a bare port such as 22.
+func parseTarget(s string) target {
+	s = strings.TrimSpace(s)
+	var t target
+	if port, err := strconv.Atoi(s); err == nil {
+		t.port = port
+		return t
+	}
+	if u, err := url.Parse(s); err == nil && u.Scheme != "" && u.Host != "" {
+		t.url = u
+		t.host = u.Hostname()
+		t.port = urlPort(u)
+	} else if host, port, err := net.SplitHostPort(s); err == nil {
+		t.host = host
+		t.port, _ = strconv.Atoi(port)
+	} else {
+		t.host = s
+	}
+	t.host = strings.ToLower(t.host)
+	t.ip = net.ParseIP(t.host).To4()
+	return t
+}
+
+// urlPort returns the port of u, or the default port of its scheme.
+func urlPort(u *url.URL) int {
+	if port, err := strconv.Atoi(u.Port()); err == nil {
+		return port
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		return 80
+	case "https":
+		return 443
+	}
+	return 0
+}
+
+// Matches reports whether the exclusion applies to target, which is a URL
+// such as https://example.com/admin, a host with an optional port such as
+// 10.0.0.1:8443, or a bare port such as 22. Exclusions of an unknown type or
+// with a malformed value match nothing.
+//
+// Matches is a client-side approximation. The Lighthouse API does not
+// document how its scanners evaluate exclusions, so the matching rules, such
+// as wildcard hostnames covering subdomains only and URLs covering the paths
+// below them, are this package's own and may differ from the server's. Use it
+// to preview which targets are excluded, not to decide what gets scanned.
+func (e ScanObjectExclusion) Matches(target string) bool {
+	t := parseTarget(target)
+	value := strings.TrimSpace(e.Value)
+	switch e.Type {
+	case api.ExclusionTypeIPv4:
+		ip := net.ParseIP(value)
+		return t.ip != nil && ip != nil && ip.Equal(t.ip)
+	case api.ExclusionTypeIPv4Range:
+		return t.ip != nil && inRange(value, t.ip)
+	case api.ExclusionTypeHostname:
+		value = strings.ToLower(value)
+		if suffix, ok := strings.CutPrefix(value, "*."); ok {
+			return strings.HasSuffix(t.host, "."+suffix)
+		}
+		return t.host != "" && t.host == value
+	case api.ExclusionTypeURL:
+		u, err := url.Parse(value)
+		if err != nil || t.url == nil || u.Host == "" {
+			return false
+		}
+		return strings.EqualFold(u.Scheme, t.url.Scheme) &&
+			strings.EqualFold(u.Hostname(), t.host) &&
+			urlPort(u) == t.port &&
+			underPath(t.url.Path, u.Path)
+	case api.ExclusionTypePort:
+		from, to, ok := portRange(value)
+		return ok && t.port != 0 && t.port >= from && t.port <= to
+	}
+	return false
+}
+
+// inRange reports whether ip is in value, a CIDR block or an address range
+// such as 10.0.0.1-10.0.0.20.
+func inRange(value string, ip net.IP) bool {
+	if _, block, err := net.ParseCIDR(value); err == nil {
+		return block.Contains(ip)
+	}
+	first, last, ok := strings.Cut(value, "-")
+	if !ok {
+		return false
+	}
+	from := net.ParseIP(strings.TrimSpace(first)).To4()
+	to := net.ParseIP(strings.TrimSpace(last)).To4()
+	if from == nil || to == nil {
+		return false
+	}
+	return compareIPs(ip, from) >= 0 && compareIPs(ip, to) <= 0
+}
+
+func compareIPs(a, b net.IP) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return int(a[i]) - int(b[i])
+		}
+	}
+	return 0
+}
+
+// underPath reports whether path is prefix or a path below it.
+func underPath(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// portRange parses a port such as 22 or a range such as 8000-8100.
+func portRange(value string) (int, int, bool) {
+	first, last, isRange := strings.Cut(value, "-")
+	from, err := strconv.Atoi(strings.TrimSpace(first))
+	if err != nil {
+		return 0, 0, false
+	}
+	if !isRange {
+		return from, from, true
+	}
+	to, err := strconv.Atoi(strings.TrimSpace(last))
+	if err != nil {
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// Excluded returns the first exclusion of the scan object that matches
+// target, as described for ScanObjectExclusion.Matches. Like Matches, it is a
+// client-side approximation of the server's rules. The exclusions must have
+// been loaded, for example with With("exclusions").
+func (s ScanObject) Excluded(target string) (ScanObjectExclusion, bool) {
+	for _, e := range s.Exclusions {
+		if e.Matches(target) {
+			return e, true
+		}
+	}
+	return ScanObjectExclusion{}, false
+}
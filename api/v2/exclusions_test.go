@@ -0,0 +1,56 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanObjectExclusion_Matches(t *testing.T) {
+	tests := []struct {
+		typ    api.ExclusionType
+		value  string
+		target string
+		want   bool
+	}{
+		{api.ExclusionTypeIPv4, "10.0.0.20", "10.0.0.20", true},
+		{api.ExclusionTypeIPv4, "10.0.0.20", "https://10.0.0.20:8443/login", true},
+		{api.ExclusionTypeIPv4, "10.0.0.20", "10.0.0.21", false},
+		{api.ExclusionTypeIPv4Range, "10.0.0.0/24", "10.0.0.99:22", true},
+		{api.ExclusionTypeIPv4Range, "10.0.0.0/24", "10.0.1.1", false},
+		{api.ExclusionTypeIPv4Range, "10.0.0.1-10.0.0.20", "10.0.0.20", true},
+		{api.ExclusionTypeIPv4Range, "10.0.0.1-10.0.0.20", "10.0.0.21", false},
+		{api.ExclusionTypeHostname, "Printer.example.com", "printer.example.com", true},
+		{api.ExclusionTypeHostname, "*.example.com", "https://intranet.example.com/", true},
+		{api.ExclusionTypeHostname, "*.example.com", "example.com", false},
+		{api.ExclusionTypeURL, "https://example.com/admin", "https://example.com/admin/users", true},
+		{api.ExclusionTypeURL, "https://example.com/admin", "https://example.com:443/admin", true},
+		{api.ExclusionTypeURL, "https://example.com/admin", "https://example.com/administrator", false},
+		{api.ExclusionTypeURL, "https://example.com/admin", "http://example.com/admin", false},
+		{api.ExclusionTypePort, "22", "10.0.0.1:22", true},
+		{api.ExclusionTypePort, "22", "22", true},
+		{api.ExclusionTypePort, "8000-8100", "http://example.com:8080/", true},
+		{api.ExclusionTypePort, "8000-8100", "https://example.com/", false},
+		{api.ExclusionTypePort, "22", "example.com", false},
+		{"unknown", "10.0.0.20", "10.0.0.20", false},
+	}
+	for _, tt := range tests {
+		e := ScanObjectExclusion{Type: tt.typ, Value: tt.value}
+		assert.Equal(t, tt.want, e.Matches(tt.target), "%s %s matching %s", tt.typ, tt.value, tt.target)
+	}
+}
+
+func TestScanObject_Excluded(t *testing.T) {
+	s := ScanObject{Exclusions: []ScanObjectExclusion{
+		{Name: "SSH", Type: api.ExclusionTypePort, Value: "22"},
+		{Name: "Printers", Type: api.ExclusionTypeIPv4Range, Value: "10.0.0.0/28"},
+	}}
+
+	e, ok := s.Excluded("10.0.0.5:631")
+	assert.True(t, ok)
+	assert.Equal(t, "Printers", e.Name)
+
+	_, ok = s.Excluded("10.0.1.5:443")
+	assert.False(t, ok)
+}
@@ -0,0 +1,4 @@
+package v2
+
+//go:generate go run ../../internal/apigen -spec ../../openapi/lighthouse-v2.yaml -api resources_gen.go -fixtures ../../lighthousetest/fixtures_gen.go
+//go:generate go run ../../internal/buildergen
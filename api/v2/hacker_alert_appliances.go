@@ -0,0 +1,235 @@
+package v2
+
+import (
+	"encoding/json"
+
+	"github.com/guardian360/go-lighthouse/api"
+	"github.com/guardian360/go-lighthouse/client"
+)
+
+// HackerAlertAppliance represents a hacker alert appliance in the Lighthouse
+// API. Hacker alert appliances are honeypots that raise an alert when they
+// are probed or logged in to.
+type HackerAlertAppliance struct {
+	// ID is the unique identifier for the hacker alert appliance.
+	ID string `json:"id"`
+	// Name is the name of the hacker alert appliance.
+	Name string `json:"name"`
+	// Description is a description of the hacker alert appliance.
+	Description string `json:"description"`
+	// Hypervisor is the type of hypervisor used by the hacker alert appliance.
+	Hypervisor string `json:"hypervisor"`
+	// NetworkType is the type of network configuration for the hacker alert
+	// appliance (DHCP or static).
+	NetworkType api.NetworkType `json:"network_type"`
+	// IPv4 is the IPv4 address of the hacker alert appliance.
+	IPv4 string `json:"ipv4"`
+	// Subnet is the subnet mask for the hacker alert appliance's network
+	// configuration.
+	Subnet string `json:"subnet"`
+	// Gateway is the gateway address for the hacker alert appliance's network
+	// configuration.
+	Gateway string `json:"gateway"`
+	// DNS1 is the primary DNS server for the hacker alert appliance.
+	DNS1 string `json:"dns1"`
+	// DNS2 is the secondary DNS server for the hacker alert appliance.
+	DNS2 string `json:"dns2"`
+	// DNS3 is an optional tertiary DNS server for the hacker alert appliance.
+	DNS3 string `json:"dns3"`
+	// ImageLocation is the location of the hacker alert appliance's image
+	// archive file.
+	ImageLocation string `json:"image_location"`
+	// NotificationEmails contains the email addresses to which alerts raised
+	// by the hacker alert appliance are sent.
+	NotificationEmails string `json:"notification_emails"`
+	// Status is the current status of the hacker alert appliance (e.g.,
+	// online, offline).
+	Status api.ProbeStatus `json:"status"`
+	// CurrentIPv4Address is the current IPv4 address of the hacker alert
+	// appliance, if it has been assigned one.
+	CurrentIPv4Address string `json:"current_ipv4_address"`
+	// CPUCores is the number of CPU cores allocated to the hacker alert
+	// appliance.
+	CPUCores api.FlexInt `json:"cpu_cores"`
+	// Memory is the amount of memory allocated to the hacker alert appliance,
+	// in MB.
+	Memory api.FlexString `json:"memory"`
+	// MemoryBytes is the amount of memory allocated to the hacker alert
+	// appliance, in bytes.
+	MemoryBytes int64 `json:"memory_bytes"`
+	// Reference is a reference string for the hacker alert appliance, which
+	// can be used to identify it in other contexts.
+	Reference string `json:"reference"`
+	// ActiveContract is the number of active contracts associated with the
+	// hacker alert appliance.
+	ActiveContract int `json:"activeContract"`
+	// Company is the company that owns the hacker alert appliance, included
+	// via ?with=company.
+	Company *Company `json:"company,omitempty"`
+	// CreatedAt is the timestamp when the hacker alert appliance was created.
+	CreatedAt api.Time `json:"created_at"`
+	// UpdatedAt is the timestamp when the hacker alert appliance was last
+	// updated.
+	UpdatedAt api.Time `json:"updated_at"`
+	// DeletedAt is the timestamp when the hacker alert appliance was deleted,
+	// if applicable.
+	DeletedAt api.Time `json:"deleted_at,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a hacker alert appliance, keeping unknown fields in
+// Extra.
+func (h *HackerAlertAppliance) UnmarshalJSON(data []byte) error {
+	type hackerAlertAppliance HackerAlertAppliance
+	return api.UnmarshalWithExtra(data, (*hackerAlertAppliance)(h), &h.Extra)
+}
+
+// MarshalJSON encodes a hacker alert appliance, including the fields in
+// Extra.
+func (h HackerAlertAppliance) MarshalJSON() ([]byte, error) {
+	type hackerAlertAppliance HackerAlertAppliance
+	return api.MarshalWithExtra(hackerAlertAppliance(h), h.Extra)
+}
+
+// Fields of HackerAlertAppliance, for filtering, sorting and selecting with
+// HackerAlertAppliancesAPI.Where, SortBy and Fields.
+var (
+	HackerAlertApplianceID                 = api.NewField[HackerAlertAppliance]("id")
+	HackerAlertApplianceName               = api.NewField[HackerAlertAppliance]("name")
+	HackerAlertApplianceDescription        = api.NewField[HackerAlertAppliance]("description")
+	HackerAlertApplianceHypervisor         = api.NewField[HackerAlertAppliance]("hypervisor")
+	HackerAlertApplianceNetworkType        = api.NewField[HackerAlertAppliance]("network_type")
+	HackerAlertApplianceIPv4               = api.NewField[HackerAlertAppliance]("ipv4")
+	HackerAlertApplianceStatus             = api.NewField[HackerAlertAppliance]("status")
+	HackerAlertApplianceCurrentIPv4Address = api.NewField[HackerAlertAppliance]("current_ipv4_address")
+	HackerAlertApplianceReference          = api.NewField[HackerAlertAppliance]("reference")
+	HackerAlertApplianceCreatedAt          = api.NewField[HackerAlertAppliance]("created_at")
+	HackerAlertApplianceUpdatedAt          = api.NewField[HackerAlertAppliance]("updated_at")
+)
+
+// HackerAlertAppliancesAPI is the API for the hacker alert appliances
+// resource.
+type HackerAlertAppliancesAPI struct {
+	api.Collection[HackerAlertAppliance]
+}
+
+// HackerAlertAppliancesAPIResponse is the response structure for the hacker
+// alert appliances API.
+type HackerAlertAppliancesAPIResponse = api.List[HackerAlertAppliance]
+
+// NewHackerAlertAppliancesAPI creates a new HackerAlertAppliancesAPI
+// instance.
+func NewHackerAlertAppliancesAPI(c *client.Client) *HackerAlertAppliancesAPI {
+	return &HackerAlertAppliancesAPI{Collection: *api.NewCollection[HackerAlertAppliance](c, c.BaseURL+"/api/v2/hacker-alert-appliances")}
+}
+
+// HackerAlertApplianceAPI is the API for a single hacker alert appliance.
+type HackerAlertApplianceAPI struct {
+	api.Item[HackerAlertAppliance]
+}
+
+// HackerAlertApplianceAPIResponse is the response structure for a single
+// hacker alert appliance.
+type HackerAlertApplianceAPIResponse = api.Response[HackerAlertAppliance]
+
+// NewHackerAlertApplianceAPI creates a new HackerAlertApplianceAPI instance.
+func NewHackerAlertApplianceAPI(c *client.Client, id string) *HackerAlertApplianceAPI {
+	return &HackerAlertApplianceAPI{Item: *api.NewItem[HackerAlertAppliance](c, c.BaseURL+"/api/v2/hacker-alert-appliances/"+id, id)}
+}
+
+// Alerts retrieves the alerts raised by a hacker alert appliance.
+func (h *HackerAlertApplianceAPI) Alerts() *HackerAlertsAPI {
+	return NewHackerAlertsAPI(h.Client, h.ID)
+}
+
+// Alert retrieves the API for a single alert raised by a hacker alert
+// appliance.
+func (h *HackerAlertApplianceAPI) Alert(id string) *HackerAlertAPI {
+	return NewHackerAlertAPI(h.Client, h.ID, id)
+}
+
+// HackerAlert represents an alert raised by a hacker alert appliance, such
+// as a connection to one of its decoy services.
+type HackerAlert struct {
+	// ID is the unique identifier for the alert.
+	ID string `json:"id"`
+	// HackerAlertApplianceID is the ID of the hacker alert appliance that
+	// raised the alert.
+	HackerAlertApplianceID string `json:"hacker_alert_appliance_id"`
+	// Type is the kind of event that raised the alert (e.g., "port-scan",
+	// "ssh-login").
+	Type string `json:"type"`
+	// SourceIP is the IP address the event came from.
+	SourceIP string `json:"source_ip"`
+	// SourcePort is the port the event came from.
+	SourcePort api.FlexInt `json:"source_port"`
+	// DestinationPort is the port on the appliance the event was aimed at.
+	DestinationPort api.FlexInt `json:"destination_port"`
+	// Protocol is the protocol of the event (e.g., "tcp", "udp").
+	Protocol string `json:"protocol"`
+	// Details contains event specific details, such as the username tried.
+	Details map[string]interface{} `json:"details"`
+	// OccurredAt is the timestamp when the event occurred.
+	OccurredAt api.Time `json:"occurred_at"`
+	// CreatedAt is the timestamp when the alert was created.
+	CreatedAt api.Time `json:"created_at"`
+	// UpdatedAt is the timestamp when the alert was last updated.
+	UpdatedAt api.Time `json:"updated_at"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a hacker alert, keeping unknown fields in Extra.
+func (h *HackerAlert) UnmarshalJSON(data []byte) error {
+	type hackerAlert HackerAlert
+	return api.UnmarshalWithExtra(data, (*hackerAlert)(h), &h.Extra)
+}
+
+// MarshalJSON encodes a hacker alert, including the fields in Extra.
+func (h HackerAlert) MarshalJSON() ([]byte, error) {
+	type hackerAlert HackerAlert
+	return api.MarshalWithExtra(hackerAlert(h), h.Extra)
+}
+
+// Fields of HackerAlert, for filtering, sorting and selecting with
+// HackerAlertsAPI.Where, SortBy and Fields.
+var (
+	HackerAlertID              = api.NewField[HackerAlert]("id")
+	HackerAlertType            = api.NewField[HackerAlert]("type")
+	HackerAlertSourceIP        = api.NewField[HackerAlert]("source_ip")
+	HackerAlertSourcePort      = api.NewField[HackerAlert]("source_port")
+	HackerAlertDestinationPort = api.NewField[HackerAlert]("destination_port")
+	HackerAlertProtocol        = api.NewField[HackerAlert]("protocol")
+	HackerAlertOccurredAt      = api.NewField[HackerAlert]("occurred_at")
+	HackerAlertCreatedAt       = api.NewField[HackerAlert]("created_at")
+)
+
+// HackerAlertsAPI is the API for the alerts of a hacker alert appliance.
+type HackerAlertsAPI struct {
+	api.Collection[HackerAlert]
+}
+
+// HackerAlertsAPIResponse is the response structure for the hacker alerts
+// API.
+type HackerAlertsAPIResponse = api.List[HackerAlert]
+
+// NewHackerAlertsAPI creates a new HackerAlertsAPI instance for the alerts
+// of a hacker alert appliance.
+func NewHackerAlertsAPI(c *client.Client, applianceID string) *HackerAlertsAPI {
+	return &HackerAlertsAPI{Collection: *api.NewCollection[HackerAlert](c, c.BaseURL+"/api/v2/hacker-alert-appliances/"+applianceID+"/alerts")}
+}
+
+// HackerAlertAPI is the API for a single alert of a hacker alert appliance.
+type HackerAlertAPI struct {
+	api.Item[HackerAlert]
+}
+
+// HackerAlertAPIResponse is the response structure for a single hacker
+// alert.
+type HackerAlertAPIResponse = api.Response[HackerAlert]
+
+// NewHackerAlertAPI creates a new HackerAlertAPI instance.
+func NewHackerAlertAPI(c *client.Client, applianceID, id string) *HackerAlertAPI {
+	return &HackerAlertAPI{Item: *api.NewItem[HackerAlert](c, c.BaseURL+"/api/v2/hacker-alert-appliances/"+applianceID+"/alerts/"+id, id)}
+}
@@ -0,0 +1,59 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/api"
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHackerAlertAppliance_JSON(t *testing.T) {
+	var appliance HackerAlertAppliance
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "h1",
+		"status": "online",
+		"cpu_cores": "2",
+		"image_location": "https://images.example.com/h1.ova",
+		"memory_bytes": 2147483648,
+		"reference": "REF-1",
+		"activeContract": 1,
+		"company": {"id": "c1", "name": "ACME"}
+	}`), &appliance))
+
+	assert.Equal(t, api.ProbeStatusOnline, appliance.Status)
+	assert.Equal(t, 2, appliance.CPUCores.Int())
+	assert.Equal(t, "https://images.example.com/h1.ova", appliance.ImageLocation)
+	assert.Equal(t, int64(2147483648), appliance.MemoryBytes)
+	assert.Equal(t, "REF-1", appliance.Reference)
+	assert.Equal(t, 1, appliance.ActiveContract)
+	require.NotNil(t, appliance.Company)
+	assert.Equal(t, "ACME", appliance.Company.Name)
+	assert.Empty(t, appliance.Extra)
+}
+
+func TestHackerAlertApplianceAPI_Alerts(t *testing.T) {
+	appliance := New(client.New("http://example.com")).HackerAlertAppliance("h1")
+
+	alerts := appliance.Alerts().SortBy(HackerAlertDestinationPort.Desc())
+	assert.IsType(t, &HackerAlertsAPI{}, alerts)
+	assert.Equal(t, "http://example.com/api/v2/hacker-alert-appliances/h1/alerts?sort=-destination_port", alerts.BuildURL())
+	assert.Equal(t, "http://example.com/api/v2/hacker-alert-appliances/h1/alerts/a1", appliance.Alert("a1").BuildURL())
+}
+
+func TestHeartbeatAPI_Get(t *testing.T) {
+	var path string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+
+	heartbeat, err := New(c).Heartbeat().Get()
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v2/heartbeat", path)
+	assert.Empty(t, heartbeat.Data)
+}
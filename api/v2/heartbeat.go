@@ -0,0 +1,33 @@
+package v2
+
+import (
+	"github.com/guardian360/go-lighthouse/api"
+	"github.com/guardian360/go-lighthouse/client"
+)
+
+// HeartbeatAPI is the v2 API for the heartbeat endpoint, which reports that
+// the API is alive like the v1 heartbeat.
+type HeartbeatAPI struct {
+	api.APIRequestHandler
+}
+
+// HeartbeatAPIResponse represents the response structure for the heartbeat API.
+type HeartbeatAPIResponse struct {
+	// Data contains the heartbeat data.
+	Data []interface{} `json:"data"`
+}
+
+// NewHeartbeatAPI creates a new HeartbeatAPI instance.
+func NewHeartbeatAPI(c *client.Client) *HeartbeatAPI {
+	return &HeartbeatAPI{
+		APIRequestHandler: api.APIRequestHandler{
+			Client:  c,
+			BaseURL: c.BaseURL + "/api/v2/heartbeat",
+		},
+	}
+}
+
+// Get retrieves the API heartbeat response.
+func (h *HeartbeatAPI) Get() (*HeartbeatAPIResponse, error) {
+	return api.Do[HeartbeatAPIResponse](h.APIRequestHandler, "GET", h.BuildURL(), nil)
+}
@@ -1,8 +1,7 @@
 package v2
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
 
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
@@ -18,45 +17,52 @@ type HostDiscovery struct {
 	IP string `json:"ip"`
 	// Ports is a list of ports that were discovered open on the host.
 	Ports []struct {
-		Port     int    `json:"port"`
-		Protocol string `json:"protocol"`
-		TLS      bool   `json:"tls"`
+		Port     api.FlexInt  `json:"port"`
+		Protocol string       `json:"protocol"`
+		TLS      api.FlexBool `json:"tls"`
 	} `json:"ports"`
 	// CreatedAt is the timestamp when the discovery was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the discovery was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// UnmarshalJSON decodes a host discovery, keeping unknown fields in Extra.
+func (h *HostDiscovery) UnmarshalJSON(data []byte) error {
+	type hostDiscovery HostDiscovery
+	return api.UnmarshalWithExtra(data, (*hostDiscovery)(h), &h.Extra)
+}
+
+// MarshalJSON encodes a host discovery, including the fields in Extra.
+func (h HostDiscovery) MarshalJSON() ([]byte, error) {
+	type hostDiscovery HostDiscovery
+	return api.MarshalWithExtra(hostDiscovery(h), h.Extra)
+}
+
+// Fields of HostDiscovery, for filtering, sorting and selecting with
+// HostDiscoveriesAPI.Where, SortBy and Fields.
+var (
+	HostDiscoveryID        = api.NewField[HostDiscovery]("id")
+	HostDiscoveryHost      = api.NewField[HostDiscovery]("host")
+	HostDiscoveryIP        = api.NewField[HostDiscovery]("ip")
+	HostDiscoveryCreatedAt = api.NewField[HostDiscovery]("created_at")
+	HostDiscoveryUpdatedAt = api.NewField[HostDiscovery]("updated_at")
+)
+
 // HostDiscoveriesAPI is the API for the host discoveries resource.
 type HostDiscoveriesAPI struct {
-	api.APIRequestHandler
+	api.Collection[HostDiscovery]
 }
 
 // HostDiscoveriesAPIResponse is the response structure for the host
 // discoveries API.
-type HostDiscoveriesAPIResponse struct {
-	// Data contains the list of host discoveries.
-	Data []HostDiscovery `json:"data"`
-	// Links contains pagination and other links.
-	Links APIResponseLinks `json:"links,omitempty"`
-	// Meta contains metadata about the response.
-	Meta APIResponseMeta `json:"meta,omitempty"`
-}
+type HostDiscoveriesAPIResponse = api.List[HostDiscovery]
 
 // NewHostDiscoveriesAPI creates a new HostDiscoveriesAPI instance.
 func NewHostDiscoveriesAPI(c *client.Client) *HostDiscoveriesAPI {
-	return &HostDiscoveriesAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/host-discoveries",
-		},
-	}
-}
-
-// Get retrieves a list of host discoveries.
-func (h *HostDiscoveriesAPI) Get() (*HostDiscoveriesAPIResponse, error) {
-	return api.Do[HostDiscoveriesAPIResponse](h.APIRequestHandler, "GET", h.BuildURL(), nil)
+	return &HostDiscoveriesAPI{Collection: *api.NewCollection[HostDiscovery](c, c.BaseURL+"/api/v2/host-discoveries")}
 }
 
 // Upsert creates or updates a host discovery.
@@ -64,56 +70,16 @@ func (h *HostDiscoveriesAPI) Upsert(data api.APIRequestPayload) (*HostDiscoveryA
 	return api.Do[HostDiscoveryAPIResponse](h.APIRequestHandler, "POST", h.BuildURL(), data)
 }
 
-// Page sets the page number for pagination.
-func (p *HostDiscoveriesAPI) Page(page int) *HostDiscoveriesAPI {
-	p.SetParam("page", fmt.Sprintf("%d", page))
-	return p
-}
-
-// PerPage sets the number of items per page for pagination.
-func (p *HostDiscoveriesAPI) PerPage(perPage int) *HostDiscoveriesAPI {
-	p.SetParam("per_page", fmt.Sprintf("%d", perPage))
-	return p
-}
-
-// Scopes sets the scopes to filter by.
-func (p *HostDiscoveriesAPI) Scopes(scopes ...string) *HostDiscoveriesAPI {
-	p.SetParam("scopes", strings.Join(scopes, ","))
-	return p
-}
-
-// Sort sets the sorting key and order.
-func (p *HostDiscoveriesAPI) Sort(sort, order string) *HostDiscoveriesAPI {
-	p.SetParam("sort", sort+","+order)
-	return p
-}
-
 // HostDiscoveryAPI is the API for a single host discovery instance.
 type HostDiscoveryAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the host discovery.
-	ID string
+	api.Item[HostDiscovery]
 }
 
 // HostDiscoveryAPIResponse is the response structure for a single host
 // discovery.
-type HostDiscoveryAPIResponse struct {
-	// Data contains the host discovery details.
-	Data HostDiscovery `json:"data"`
-}
+type HostDiscoveryAPIResponse = api.Response[HostDiscovery]
 
 // NewHostDiscoveryAPI creates a new HostDiscoveryAPI instance.
 func NewHostDiscoveryAPI(c *client.Client, id string) *HostDiscoveryAPI {
-	return &HostDiscoveryAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/host-discoveries/" + id,
-		},
-		ID: id,
-	}
-}
-
-// Get retrieves a single host discovery by ID.
-func (h *HostDiscoveryAPI) Get() (*HostDiscoveryAPIResponse, error) {
-	return api.Do[HostDiscoveryAPIResponse](h.APIRequestHandler, "GET", h.BuildURL(), nil)
+	return &HostDiscoveryAPI{Item: *api.NewItem[HostDiscovery](c, c.BaseURL+"/api/v2/host-discoveries/"+id, id)}
 }
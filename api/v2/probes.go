@@ -1,8 +1,7 @@
 package v2
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
 
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
@@ -20,7 +19,7 @@ type Probe struct {
 	Hypervisor string `json:"hypervisor"`
 	// NetworkType is the type of network configuration for the probe (DHCP or
 	// static).
-	NetworkType string `json:"network_type"`
+	NetworkType api.NetworkType `json:"network_type"`
 	// IPv4 is the IPv4 address of the probe.
 	IPv4 string `json:"ipv4"`
 	// Subnet is the subnet mask for the probe's network configuration.
@@ -34,11 +33,11 @@ type Probe struct {
 	// DNS3 is an optional tertiary DNS server for the probe.
 	DNS3 string `json:"dns3"`
 	// Status is the current status of the probe (e.g., online, offline).
-	Status string `json:"status"`
+	Status api.ProbeStatus `json:"status"`
 	// CPUCores is the number of CPU cores allocated to the probe.
-	CPUCores int `json:"cpu_cores"`
+	CPUCores api.FlexInt `json:"cpu_cores"`
 	// Memory is the amount of memory allocated to the probe, in MB.
-	Memory string `json:"memory"`
+	Memory api.FlexString `json:"memory"`
 	// ScannerVersion is the version of the scanner running on the probe.
 	ScannerVersion string `json:"scanner_version"`
 	// Company is the company that owns the probe. Included via ?with=company.
@@ -47,115 +46,74 @@ type Probe struct {
 	// Included via ?with=scannerplatform.
 	ScannerPlatform *ScannerPlatform `json:"scannerplatform,omitempty"`
 	// CreatedAt is the timestamp when the probe was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the probe was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
 	// DeletedAt is the timestamp when the probe was deleted, if applicable.
-	DeletedAt string `json:"deleted_at,omitempty"`
-}
+	DeletedAt api.Time `json:"deleted_at,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a probe, keeping unknown fields in Extra.
+func (p *Probe) UnmarshalJSON(data []byte) error {
+	type probe Probe
+	return api.UnmarshalWithExtra(data, (*probe)(p), &p.Extra)
+}
+
+// MarshalJSON encodes a probe, including the fields in Extra.
+func (p Probe) MarshalJSON() ([]byte, error) {
+	type probe Probe
+	return api.MarshalWithExtra(probe(p), p.Extra)
+}
+
+// Fields of Probe, for filtering, sorting and selecting with
+// ProbesAPI.Where, SortBy and Fields.
+var (
+	ProbeID             = api.NewField[Probe]("id")
+	ProbeName           = api.NewField[Probe]("name")
+	ProbeDescription    = api.NewField[Probe]("description")
+	ProbeHypervisor     = api.NewField[Probe]("hypervisor")
+	ProbeNetworkType    = api.NewField[Probe]("network_type")
+	ProbeIPv4           = api.NewField[Probe]("ipv4")
+	ProbeSubnet         = api.NewField[Probe]("subnet")
+	ProbeGateway        = api.NewField[Probe]("gateway")
+	ProbeDNS1           = api.NewField[Probe]("dns1")
+	ProbeDNS2           = api.NewField[Probe]("dns2")
+	ProbeDNS3           = api.NewField[Probe]("dns3")
+	ProbeStatus         = api.NewField[Probe]("status")
+	ProbeCPUCores       = api.NewField[Probe]("cpu_cores")
+	ProbeMemory         = api.NewField[Probe]("memory")
+	ProbeScannerVersion = api.NewField[Probe]("scanner_version")
+	ProbeCreatedAt      = api.NewField[Probe]("created_at")
+	ProbeUpdatedAt      = api.NewField[Probe]("updated_at")
+	ProbeDeletedAt      = api.NewField[Probe]("deleted_at")
+)
 
 // ProbesAPI is the API for the probes resource.
 type ProbesAPI struct {
-	api.APIRequestHandler
+	api.Collection[Probe]
 }
 
 // ProbesAPIResponse is the response structure for the probes API.
-type ProbesAPIResponse struct {
-	// Data contains the list of probes.
-	Data []Probe `json:"data"`
-	// Links contains pagination and other links.
-	Links APIResponseLinks `json:"links,omitempty"`
-	// Meta contains metadata about the response.
-	Meta APIResponseMeta `json:"meta,omitempty"`
-}
+type ProbesAPIResponse = api.List[Probe]
 
 // NewProbesAPI creates a new ProbesAPI instance.
 func NewProbesAPI(c *client.Client) *ProbesAPI {
-	return &ProbesAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/probes",
-		},
-	}
-}
-
-// Get retrieves a list of probes.
-func (p *ProbesAPI) Get() (*ProbesAPIResponse, error) {
-	return api.Do[ProbesAPIResponse](p.APIRequestHandler, "GET", p.BuildURL(), nil)
-}
-
-// Create creates a new probe.
-func (p *ProbesAPI) Create(data api.APIRequestPayload) (*ProbeAPIResponse, error) {
-	return api.Do[ProbeAPIResponse](p.APIRequestHandler, "POST", p.BuildURL(), data)
-}
-
-// Page sets the page number for pagination.
-func (p *ProbesAPI) Page(page int) *ProbesAPI {
-	p.SetParam("page", fmt.Sprintf("%d", page))
-	return p
-}
-
-// PerPage sets the number of items per page for pagination.
-func (p *ProbesAPI) PerPage(perPage int) *ProbesAPI {
-	p.SetParam("per_page", fmt.Sprintf("%d", perPage))
-	return p
-}
-
-// Scopes sets the scopes to filter by.
-func (p *ProbesAPI) Scopes(scopes ...string) *ProbesAPI {
-	p.SetParam("scopes", strings.Join(scopes, ","))
-	return p
-}
-
-// Sort sets the sorting key and order.
-func (p *ProbesAPI) Sort(sort, order string) *ProbesAPI {
-	p.SetParam("sort", sort+","+order)
-	return p
+	return &ProbesAPI{Collection: *api.NewCollection[Probe](c, c.BaseURL+"/api/v2/probes")}
 }
 
 // ProbeAPI is the API for a single probe instance.
 type ProbeAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the probe.
-	ID string
+	api.Item[Probe]
 }
 
 // ProbeAPIResponse is the response structure for a single probe.
-type ProbeAPIResponse struct {
-	// Data contains the probe details.
-	Data Probe `json:"data"`
-}
+type ProbeAPIResponse = api.Response[Probe]
 
 // NewProbeAPI creates a new ProbeAPI instance.
 func NewProbeAPI(c *client.Client, id string) *ProbeAPI {
-	return &ProbeAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/probes/" + id,
-		},
-		ID: id,
-	}
-}
-
-// Get retrieves a single probe by ID.
-func (p *ProbeAPI) Get() (*ProbeAPIResponse, error) {
-	return api.Do[ProbeAPIResponse](p.APIRequestHandler, "GET", p.BuildURL(), nil)
-}
-
-// Update updates a probe.
-func (p *ProbeAPI) Update(data api.APIRequestPayload) (*ProbeAPIResponse, error) {
-	return api.Do[ProbeAPIResponse](p.APIRequestHandler, "PUT", p.BuildURL(), data)
-}
-
-// Delete deletes a probe.
-func (p *ProbeAPI) Delete() (*ProbeAPIResponse, error) {
-	return api.Do[ProbeAPIResponse](p.APIRequestHandler, "DELETE", p.BuildURL(), nil)
-}
-
-// With sets the relationships to include in the response.
-func (p *ProbeAPI) With(relationships ...string) *ProbeAPI {
-	p.SetParam("with", strings.Join(relationships, ","))
-	return p
+	return &ProbeAPI{Item: *api.NewItem[Probe](c, c.BaseURL+"/api/v2/probes/"+id, id)}
 }
 
 // Schedules retrieves the schedules for a probe.
@@ -0,0 +1,23 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/api"
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbeAPI_BuildersKeepResourceType(t *testing.T) {
+	c := client.New("http://example.com")
+	lh := New(c)
+
+	objects := lh.Probe("1").With("company").ScanObjects()
+	assert.Equal(t, "http://example.com/api/v2/probes/1/scanobjects", objects.BuildURL())
+
+	_ = lh.ScanResults().PerPage(10).Summaries
+
+	probes := lh.Probes().PerPage(10).Where(ProbeStatus.Eq(api.ProbeStatusOnline))
+	assert.IsType(t, &ProbesAPI{}, probes)
+	assert.Equal(t, "http://example.com/api/v2/probes?filter%5Bstatus%5D=online&per_page=10", probes.BuildURL())
+}
@@ -0,0 +1,126 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/guardian360/go-lighthouse/api"
+)
+
+// Target returns what a rescan of the scan result should scan: its URL if it
+// has one, otherwise its host and port.
+func (s ScanResult) Target() string {
+	if s.URL != "" {
+		return s.URL
+	}
+	if port := s.Port.String(); port != "" {
+		return s.Host + ":" + port
+	}
+	return s.Host
+}
+
+// RescanError is returned by RequestRescan when a step after creating the
+// rescan task failed. RequestRescan deletes the unstarted task; if that also
+// failed, the task is left behind and Deleted is false.
+type RescanError struct {
+	// ScanTaskID is the ID of the created rescan task.
+	ScanTaskID string
+	// Deleted reports whether the task was deleted again.
+	Deleted bool
+	// Err is the error of the failed step.
+	Err error
+}
+
+func (e *RescanError) Error() string {
+	if e.Deleted {
+		return fmt.Sprintf("v2: %v; scan task %s was deleted", e.Err, e.ScanTaskID)
+	}
+	return fmt.Sprintf("v2: %v; scan task %s was left behind", e.Err, e.ScanTaskID)
+}
+
+func (e *RescanError) Unwrap() error {
+	return e.Err
+}
+
+// RequestRescan rescans findings. It creates a rescan scan task on the
+// company, probe and scanner platform of the scan task the findings were
+// found by, registers the target and template of each finding as a rescan
+// target, associates the scan objects the findings belong to and starts the
+// task.
+//
+// All findings must carry their ScanTaskID and ScanObjectID and come from
+// scan tasks on the same probe and scanner platform. If a step after creating
+// the task fails, the task is deleted again and a *RescanError naming the
+// step is returned.
+func (s *ScanTasksAPI) RequestRescan(findings []ScanResult) (*ScanTaskAPIResponse, error) {
+	if len(findings) == 0 {
+		return nil, errors.New("v2: no findings to rescan")
+	}
+
+	var origin *ScanTask
+	tasks := map[string]bool{}
+	var scanObjectIDs []string
+	scanObjects := map[string]bool{}
+	for _, f := range findings {
+		if f.ScanTaskID == "" || f.ScanObjectID == "" {
+			return nil, fmt.Errorf("v2: scan result %s has no scan task or scan object", f.ID)
+		}
+		if !scanObjects[f.ScanObjectID] {
+			scanObjects[f.ScanObjectID] = true
+			scanObjectIDs = append(scanObjectIDs, f.ScanObjectID)
+		}
+		if tasks[f.ScanTaskID] {
+			continue
+		}
+		tasks[f.ScanTaskID] = true
+		task, err := NewScanTaskAPI(s.Client, f.ScanTaskID).Get()
+		if err != nil {
+			return nil, fmt.Errorf("v2: getting scan task %s: %w", f.ScanTaskID, err)
+		}
+		if origin == nil {
+			origin = &task.Data
+		} else if task.Data.ProbeID != origin.ProbeID || task.Data.ScannerPlatformID != origin.ScannerPlatformID {
+			return nil, fmt.Errorf("v2: scan tasks %s and %s run on different probes or scanner platforms", origin.ID, task.Data.ID)
+		}
+	}
+
+	payload := api.APIRequestPayload{"type": api.ScanTaskTypeRescan}
+	for key, value := range map[string]string{
+		"company_id":         origin.CompanyID,
+		"probe_id":           origin.ProbeID,
+		"scannerplatform_id": origin.ScannerPlatformID,
+	} {
+		if value != "" {
+			payload[key] = value
+		}
+	}
+	created, err := s.Create(payload)
+	if err != nil {
+		return nil, fmt.Errorf("v2: creating rescan task: %w", err)
+	}
+	id := created.Data.ID
+	abort := func(err error) error {
+		_, deleteErr := NewScanTaskAPI(s.Client, id).Delete()
+		return &RescanError{ScanTaskID: id, Deleted: deleteErr == nil, Err: err}
+	}
+
+	targets := NewRescanTargetsAPI(s.Client, id)
+	for _, f := range findings {
+		_, err := targets.Create(api.APIRequestPayload{
+			"scanobject_id": f.ScanObjectID,
+			"target":        f.Target(),
+			"template":      f.TemplateID,
+		})
+		if err != nil {
+			return nil, abort(fmt.Errorf("adding rescan target for scan result %s: %w", f.ID, err))
+		}
+	}
+	if _, err := NewScanTaskAPI(s.Client, id).AssociateScanObjects(scanObjectIDs); err != nil {
+		return nil, abort(fmt.Errorf("associating scan objects: %w", err))
+	}
+	started, err := NewScanTaskAPI(s.Client, id).Start()
+	if err != nil {
+		return nil, abort(fmt.Errorf("starting: %w", err))
+	}
+	return started, nil
+}
@@ -0,0 +1,254 @@
+// Code generated by apigen from lighthouse-v2.yaml. DO NOT EDIT.
+
+package v2
+
+import (
+	"encoding/json"
+
+	"github.com/guardian360/go-lighthouse/api"
+	"github.com/guardian360/go-lighthouse/client"
+)
+
+// Company represents a company in the Lighthouse API.
+type Company struct {
+	// ID is the unique identifier for the company.
+	ID string `json:"id"`
+	// Name is the name of the company.
+	Name string `json:"name"`
+	// Website is the company's website URL.
+	Website string `json:"website"`
+	// Email is the company's email address.
+	Email string `json:"email"`
+	// CreatedAt is the timestamp when the company was created.
+	CreatedAt api.Time `json:"created_at"`
+	// UpdatedAt is the timestamp when the company was last updated.
+	UpdatedAt api.Time `json:"updated_at"`
+	// DeletedAt is the timestamp when the company was deleted, if applicable.
+	DeletedAt api.Time `json:"deleted_at,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a company, keeping unknown fields in Extra.
+func (c *Company) UnmarshalJSON(data []byte) error {
+	type company Company
+	return api.UnmarshalWithExtra(data, (*company)(c), &c.Extra)
+}
+
+// MarshalJSON encodes a company, including the fields in Extra.
+func (c Company) MarshalJSON() ([]byte, error) {
+	type company Company
+	return api.MarshalWithExtra(company(c), c.Extra)
+}
+
+// Fields of Company, for filtering, sorting and selecting with
+// CompaniesAPI.Where, SortBy and Fields. CompaniesAPI sorts on CompanyName,
+// CompanyEmail, CompanyCreatedAt and CompanyUpdatedAt.
+var (
+	CompanyID        = api.NewField[Company]("id")
+	CompanyName      = api.NewField[Company]("name")
+	CompanyWebsite   = api.NewField[Company]("website")
+	CompanyEmail     = api.NewField[Company]("email")
+	CompanyCreatedAt = api.NewField[Company]("created_at")
+	CompanyUpdatedAt = api.NewField[Company]("updated_at")
+	CompanyDeletedAt = api.NewField[Company]("deleted_at")
+)
+
+// CompaniesAPI is the API for the companies resource.
+type CompaniesAPI struct {
+	api.Collection[Company]
+}
+
+// CompaniesAPIResponse is the response structure for the companies API.
+type CompaniesAPIResponse = api.List[Company]
+
+// NewCompaniesAPI creates a new CompaniesAPI instance.
+func NewCompaniesAPI(c *client.Client) *CompaniesAPI {
+	return &CompaniesAPI{Collection: *api.NewCollection[Company](c, c.BaseURL+"/api/v2/companies")}
+}
+
+// Companies retrieves the companies API.
+func (api *API) Companies() *CompaniesAPI {
+	return NewCompaniesAPI(api.Client)
+}
+
+// CompanyAPI is the API for a single company.
+type CompanyAPI struct {
+	api.Item[Company]
+}
+
+// CompanyAPIResponse is the response structure for a single company.
+type CompanyAPIResponse = api.Response[Company]
+
+// NewCompanyAPI creates a new CompanyAPI instance.
+func NewCompanyAPI(c *client.Client, id string) *CompanyAPI {
+	return &CompanyAPI{Item: *api.NewItem[Company](c, c.BaseURL+"/api/v2/companies/"+id, id)}
+}
+
+// Company retrieves the company API for a specific ID.
+func (api *API) Company(id string) *CompanyAPI {
+	return NewCompanyAPI(api.Client, id)
+}
+
+// ScanObjectExclusion represents an exclusion in a scan object.
+type ScanObjectExclusion struct {
+	// ID is the unique identifier for the exclusion.
+	ID string `json:"id"`
+	// Name is the name of the exclusion.
+	Name string `json:"name"`
+	// Type is the kind of value that is excluded.
+	Type api.ExclusionType `json:"type"`
+	// Value is the excluded host, range, URL or port.
+	Value string `json:"value"`
+	// Reason explains why the value is excluded.
+	Reason string `json:"reason"`
+	// CreatedAt is the timestamp when the exclusion was created.
+	CreatedAt api.Time `json:"created_at"`
+	// UpdatedAt is the timestamp when the exclusion was last updated.
+	UpdatedAt api.Time `json:"updated_at"`
+	// DeletedAt is the timestamp when the exclusion was deleted, if applicable.
+	DeletedAt api.Time `json:"deleted_at,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a scan object exclusion, keeping unknown fields in Extra.
+func (s *ScanObjectExclusion) UnmarshalJSON(data []byte) error {
+	type scanObjectExclusion ScanObjectExclusion
+	return api.UnmarshalWithExtra(data, (*scanObjectExclusion)(s), &s.Extra)
+}
+
+// MarshalJSON encodes a scan object exclusion, including the fields in Extra.
+func (s ScanObjectExclusion) MarshalJSON() ([]byte, error) {
+	type scanObjectExclusion ScanObjectExclusion
+	return api.MarshalWithExtra(scanObjectExclusion(s), s.Extra)
+}
+
+// Fields of ScanObjectExclusion, for filtering, sorting and selecting with
+// ScanObjectExclusionsAPI.Where, SortBy and Fields. ScanObjectExclusionsAPI
+// sorts on ScanObjectExclusionName, ScanObjectExclusionType and
+// ScanObjectExclusionCreatedAt.
+var (
+	ScanObjectExclusionID        = api.NewField[ScanObjectExclusion]("id")
+	ScanObjectExclusionName      = api.NewField[ScanObjectExclusion]("name")
+	ScanObjectExclusionType      = api.NewField[ScanObjectExclusion]("type")
+	ScanObjectExclusionValue     = api.NewField[ScanObjectExclusion]("value")
+	ScanObjectExclusionReason    = api.NewField[ScanObjectExclusion]("reason")
+	ScanObjectExclusionCreatedAt = api.NewField[ScanObjectExclusion]("created_at")
+	ScanObjectExclusionUpdatedAt = api.NewField[ScanObjectExclusion]("updated_at")
+	ScanObjectExclusionDeletedAt = api.NewField[ScanObjectExclusion]("deleted_at")
+)
+
+// ScanObjectExclusionsAPI is the API for the scan object exclusions resource.
+type ScanObjectExclusionsAPI struct {
+	api.Collection[ScanObjectExclusion]
+}
+
+// ScanObjectExclusionsAPIResponse is the response structure for the scan object exclusions API.
+type ScanObjectExclusionsAPIResponse = api.List[ScanObjectExclusion]
+
+// NewScanObjectExclusionsAPI creates a new ScanObjectExclusionsAPI instance.
+func NewScanObjectExclusionsAPI(c *client.Client, scanObjectID string) *ScanObjectExclusionsAPI {
+	return &ScanObjectExclusionsAPI{Collection: *api.NewCollection[ScanObjectExclusion](c, c.BaseURL+"/api/v2/scanobjects/"+scanObjectID+"/exclusions")}
+}
+
+// Exclusions retrieves the scan object exclusions for a scan object.
+func (s *ScanObjectAPI) Exclusions() *ScanObjectExclusionsAPI {
+	return NewScanObjectExclusionsAPI(s.Client, s.ID)
+}
+
+// ScanObjectExclusionAPI is the API for a single scan object exclusion.
+type ScanObjectExclusionAPI struct {
+	api.Item[ScanObjectExclusion]
+}
+
+// ScanObjectExclusionAPIResponse is the response structure for a single scan object exclusion.
+type ScanObjectExclusionAPIResponse = api.Response[ScanObjectExclusion]
+
+// NewScanObjectExclusionAPI creates a new ScanObjectExclusionAPI instance.
+func NewScanObjectExclusionAPI(c *client.Client, scanObjectID string, id string) *ScanObjectExclusionAPI {
+	return &ScanObjectExclusionAPI{Item: *api.NewItem[ScanObjectExclusion](c, c.BaseURL+"/api/v2/scanobjects/"+scanObjectID+"/exclusions/"+id, id)}
+}
+
+// Exclusion retrieves the scan object exclusion API for a specific ID.
+func (s *ScanObjectAPI) Exclusion(id string) *ScanObjectExclusionAPI {
+	return NewScanObjectExclusionAPI(s.Client, s.ID, id)
+}
+
+// RescanTarget represents a target for a rescan operation, included as a
+// relationship on ScanTask via ?with=rescan-targets.
+type RescanTarget struct {
+	// ID is the unique identifier for the rescan target.
+	ID int `json:"id"`
+	// ScanObjectID is the ID of the scan object the target belongs to.
+	ScanObjectID string `json:"scanobject_id"`
+	// Target is the host or URL to rescan.
+	Target string `json:"target"`
+	// Template is the ID of the template to rescan the target with.
+	Template string `json:"template"`
+	// CreatedAt is the timestamp when the rescan target was created.
+	CreatedAt api.Time `json:"created_at"`
+	// UpdatedAt is the timestamp when the rescan target was last updated.
+	UpdatedAt api.Time `json:"updated_at"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a rescan target, keeping unknown fields in Extra.
+func (r *RescanTarget) UnmarshalJSON(data []byte) error {
+	type rescanTarget RescanTarget
+	return api.UnmarshalWithExtra(data, (*rescanTarget)(r), &r.Extra)
+}
+
+// MarshalJSON encodes a rescan target, including the fields in Extra.
+func (r RescanTarget) MarshalJSON() ([]byte, error) {
+	type rescanTarget RescanTarget
+	return api.MarshalWithExtra(rescanTarget(r), r.Extra)
+}
+
+// Fields of RescanTarget, for filtering, sorting and selecting with
+// RescanTargetsAPI.Where, SortBy and Fields.
+var (
+	RescanTargetID           = api.NewField[RescanTarget]("id")
+	RescanTargetScanObjectID = api.NewField[RescanTarget]("scanobject_id")
+	RescanTargetTarget       = api.NewField[RescanTarget]("target")
+	RescanTargetTemplate     = api.NewField[RescanTarget]("template")
+	RescanTargetCreatedAt    = api.NewField[RescanTarget]("created_at")
+	RescanTargetUpdatedAt    = api.NewField[RescanTarget]("updated_at")
+)
+
+// RescanTargetsAPI is the API for the rescan targets resource.
+type RescanTargetsAPI struct {
+	api.Collection[RescanTarget]
+}
+
+// RescanTargetsAPIResponse is the response structure for the rescan targets API.
+type RescanTargetsAPIResponse = api.List[RescanTarget]
+
+// NewRescanTargetsAPI creates a new RescanTargetsAPI instance.
+func NewRescanTargetsAPI(c *client.Client, scanTaskID string) *RescanTargetsAPI {
+	return &RescanTargetsAPI{Collection: *api.NewCollection[RescanTarget](c, c.BaseURL+"/api/v2/scan-tasks/"+scanTaskID+"/rescan-targets")}
+}
+
+// RescanTargets retrieves the rescan targets for a scan task.
+func (s *ScanTaskAPI) RescanTargets() *RescanTargetsAPI {
+	return NewRescanTargetsAPI(s.Client, s.ID)
+}
+
+// RescanTargetAPI is the API for a single rescan target.
+type RescanTargetAPI struct {
+	api.Item[RescanTarget]
+}
+
+// RescanTargetAPIResponse is the response structure for a single rescan target.
+type RescanTargetAPIResponse = api.Response[RescanTarget]
+
+// NewRescanTargetAPI creates a new RescanTargetAPI instance.
+func NewRescanTargetAPI(c *client.Client, scanTaskID string, id string) *RescanTargetAPI {
+	return &RescanTargetAPI{Item: *api.NewItem[RescanTarget](c, c.BaseURL+"/api/v2/scan-tasks/"+scanTaskID+"/rescan-targets/"+id, id)}
+}
+
+// RescanTarget retrieves the rescan target API for a specific ID.
+func (s *ScanTaskAPI) RescanTarget(id string) *RescanTargetAPI {
+	return NewRescanTargetAPI(s.Client, s.ID, id)
+}
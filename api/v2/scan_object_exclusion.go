@@ -1,13 +0,0 @@
-package v2
-
-// ScanObjectExclusion represents an exclusion in a scan object.
-type ScanObjectExclusion struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Type      string `json:"type"`
-	Value     string `json:"value"`
-	Reason    string `json:"reason"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-	DeletedAt string `json:"deleted_at,omitempty"`
-}
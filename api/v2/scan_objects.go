@@ -1,8 +1,7 @@
 package v2
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
 
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
@@ -19,13 +18,13 @@ type ScanObject struct {
 	// Description is a description of the scan object.
 	Description string `json:"description"`
 	// Type is the type of the scan object ("ipv4", "ipv4-range" or "url").
-	Type string `json:"type"`
+	Type api.ScanObjectType `json:"type"`
 	// Port is the port number associated with the scan object, if applicable.
-	Port int `json:"port"`
+	Port api.FlexInt `json:"port"`
 	// SSL indicates whether SSL is enabled for the scan object.
-	SSL bool `json:"ssl"`
+	SSL api.FlexBool `json:"ssl"`
 	// Enabled indicates whether the scan object is enabled for scanning.
-	Enabled bool `json:"enabled"`
+	Enabled api.FlexBool `json:"enabled"`
 	// Company is the company that owns the scan object. Included via
 	// ?with=company.
 	Company *Company `json:"company,omitempty"`
@@ -33,112 +32,89 @@ type ScanObject struct {
 	// Included via ?with=scannerplatform.
 	ScannerPlatform *ScannerPlatform `json:"scannerplatform,omitempty"`
 	// CreatedAt is the timestamp when the scan object was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the scan object was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
 	// DeletedAt is the timestamp when the scan object was deleted, if applicable.
-	DeletedAt string `json:"deleted_at,omitempty"`
+	DeletedAt api.Time `json:"deleted_at,omitzero"`
 	// Exclusions are the exclusions associated with the scan object.
 	Exclusions []ScanObjectExclusion `json:"exclusions,omitempty"`
-}
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a scan object, keeping unknown fields in Extra.
+func (s *ScanObject) UnmarshalJSON(data []byte) error {
+	type scanObject ScanObject
+	return api.UnmarshalWithExtra(data, (*scanObject)(s), &s.Extra)
+}
+
+// MarshalJSON encodes a scan object, including the fields in Extra.
+func (s ScanObject) MarshalJSON() ([]byte, error) {
+	type scanObject ScanObject
+	return api.MarshalWithExtra(scanObject(s), s.Extra)
+}
+
+// Fields of ScanObject, for filtering, sorting and selecting with
+// ScanObjectsAPI.Where, SortBy and Fields.
+var (
+	ScanObjectID          = api.NewField[ScanObject]("id")
+	ScanObjectName        = api.NewField[ScanObject]("name")
+	ScanObjectValue       = api.NewField[ScanObject]("value")
+	ScanObjectDescription = api.NewField[ScanObject]("description")
+	ScanObjectType        = api.NewField[ScanObject]("type")
+	ScanObjectPort        = api.NewField[ScanObject]("port")
+	ScanObjectSSL         = api.NewField[ScanObject]("ssl")
+	ScanObjectEnabled     = api.NewField[ScanObject]("enabled")
+	ScanObjectCreatedAt   = api.NewField[ScanObject]("created_at")
+	ScanObjectUpdatedAt   = api.NewField[ScanObject]("updated_at")
+	ScanObjectDeletedAt   = api.NewField[ScanObject]("deleted_at")
+)
 
 // ScanObjectsAPI is the API for the scan objects resource.
 type ScanObjectsAPI struct {
-	api.APIRequestHandler
+	api.Collection[ScanObject]
 }
 
 // ScanObjectsAPIResponse is the response structure for the scan objects API.
-type ScanObjectsAPIResponse struct {
-	Data  []ScanObject     `json:"data"`
-	Links APIResponseLinks `json:"links,omitempty"`
-	Meta  APIResponseMeta  `json:"meta,omitempty"`
-}
+type ScanObjectsAPIResponse = api.List[ScanObject]
 
 // NewScanObjectsAPI creates a new ScanObjectsAPI instance.
 func NewScanObjectsAPI(c *client.Client) *ScanObjectsAPI {
-	return &ScanObjectsAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/scanobjects",
-		},
-	}
-}
-
-// Get retrieves a list of scan objects.
-func (s *ScanObjectsAPI) Get() (*ScanObjectsAPIResponse, error) {
-	return api.Do[ScanObjectsAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Create creates a new scan object.
-func (s *ScanObjectsAPI) Create(data api.APIRequestPayload) (*ScanObjectAPIResponse, error) {
-	return api.Do[ScanObjectAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), data)
-}
-
-// Page sets the page number for pagination.
-func (p *ScanObjectsAPI) Page(page int) *ScanObjectsAPI {
-	p.SetParam("page", fmt.Sprintf("%d", page))
-	return p
-}
-
-// PerPage sets the number of items per page for pagination.
-func (p *ScanObjectsAPI) PerPage(perPage int) *ScanObjectsAPI {
-	p.SetParam("per_page", fmt.Sprintf("%d", perPage))
-	return p
-}
-
-// With sets the relationships to include in the response.
-func (p *ScanObjectsAPI) With(relationships ...string) *ScanObjectsAPI {
-	p.SetParam("with", strings.Join(relationships, ","))
-	return p
-}
-
-// Scopes sets the scopes to filter by.
-func (p *ScanObjectsAPI) Scopes(scopes ...string) *ScanObjectsAPI {
-	p.SetParam("scopes", strings.Join(scopes, ","))
-	return p
-}
-
-// Sort sets the sorting key and order.
-func (p *ScanObjectsAPI) Sort(sort, order string) *ScanObjectsAPI {
-	p.SetParam("sort", sort+","+order)
-	return p
+	return &ScanObjectsAPI{Collection: *api.NewCollection[ScanObject](c, c.BaseURL+"/api/v2/scanobjects")}
 }
 
 // ScanObjectAPI is the API for a specific scan object.
 type ScanObjectAPI struct {
-	api.APIRequestHandler
-	ID string
+	api.Item[ScanObject]
 }
 
 // ScanObjectAPIResponse is the response structure for a single scan object.
-type ScanObjectAPIResponse struct {
-	// Data contains the scan object details.
-	Data ScanObject `json:"data"`
-}
+type ScanObjectAPIResponse = api.Response[ScanObject]
 
 // NewScanObjectAPI creates a new ScanObjectAPI instance for a specific scan
 // object.
 func NewScanObjectAPI(c *client.Client, id string) *ScanObjectAPI {
-	return &ScanObjectAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/scanobjects/" + id,
-		},
-		ID: id,
-	}
+	return &ScanObjectAPI{Item: *api.NewItem[ScanObject](c, c.BaseURL+"/api/v2/scanobjects/"+id, id)}
 }
 
-// Get retrieves the details of a specific scan object by its ID.
-func (s *ScanObjectAPI) Get() (*ScanObjectAPIResponse, error) {
-	return api.Do[ScanObjectAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
+// ScanResults retrieves the scan results found for a scan object.
+func (s *ScanObjectAPI) ScanResults() *ScanResultsAPI {
+	scanResultsAPI := NewScanResultsAPI(s.Client)
+	scanResultsAPI.BaseURL = s.BaseURL + "/scan-results"
+	return scanResultsAPI
 }
 
-// Update updates the details of a specific scan object.
-func (s *ScanObjectAPI) Update(data api.APIRequestPayload) (*ScanObjectAPIResponse, error) {
-	return api.Do[ScanObjectAPIResponse](s.APIRequestHandler, "PUT", s.BuildURL(), data)
+// HostDiscoveries retrieves the host discoveries for a scan object.
+func (s *ScanObjectAPI) HostDiscoveries() *HostDiscoveriesAPI {
+	hostDiscoveriesAPI := NewHostDiscoveriesAPI(s.Client)
+	hostDiscoveriesAPI.BaseURL = s.BaseURL + "/host-discoveries"
+	return hostDiscoveriesAPI
 }
 
-// Delete removes a specific scan object by its ID.
-func (s *ScanObjectAPI) Delete() (*ScanObjectAPIResponse, error) {
-	return api.Do[ScanObjectAPIResponse](s.APIRequestHandler, "DELETE", s.BuildURL(), nil)
+// ScanTasks retrieves the scan tasks that cover a scan object.
+func (s *ScanObjectAPI) ScanTasks() *ScanTasksAPI {
+	scanTasksAPI := NewScanTasksAPI(s.Client)
+	scanTasksAPI.BaseURL = s.BaseURL + "/scan-tasks"
+	return scanTasksAPI
 }
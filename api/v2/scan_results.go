@@ -1,8 +1,7 @@
 package v2
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
 
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
@@ -13,6 +12,10 @@ import (
 type ScanResult struct {
 	// ID is the unique identifier for the scan result.
 	ID string `json:"id"`
+	// ScanTaskID is the ID of the scan task that found the scan result.
+	ScanTaskID string `json:"scan_task_id,omitempty"`
+	// ScanObjectID is the ID of the scan object the scan result belongs to.
+	ScanObjectID string `json:"scanobject_id,omitempty"`
 	// Template is the template used for the scan result.
 	Template string `json:"template"`
 	// TemplateURL is the URL of the template used for the scan result.
@@ -34,7 +37,7 @@ type ScanResult struct {
 	// Host is the hostname or IP address of the scan result.
 	Host string `json:"host"`
 	// Port is the port number associated with the scan result.
-	Port string `json:"port"`
+	Port api.FlexString `json:"port"`
 	// Scheme is the scheme used for the scan result (e.g., "http", "https").
 	Scheme string `json:"scheme"`
 	// URL is the URL of the scan result, if applicable.
@@ -42,7 +45,7 @@ type ScanResult struct {
 	// Path is the path of the scan result, if applicable.
 	Path string `json:"path"`
 	// MatchedAt is the timestamp when the scan result was matched.
-	MatchedAt string `json:"matched_at"`
+	MatchedAt api.Time `json:"matched_at"`
 	// extracted_results: null,
 	// ExtractedResults is the results extracted from the scan result, if
 	// applicable.
@@ -56,7 +59,7 @@ type ScanResult struct {
 	// IP is the IP address associated with the scan result, if applicable.
 	IP string `json:"ip"`
 	// Timestamp is the timestamp when the scan result was created.
-	Timestamp string `json:"timestamp"`
+	Timestamp api.Time `json:"timestamp"`
 	// Interaction is the interaction associated with the scan result, if
 	// applicable.
 	Interaction *server.Interaction `json:"interaction"`
@@ -88,39 +91,66 @@ type ScanResult struct {
 	// Error is any error associated with the scan result, if applicable.
 	Error string `json:"error,omitempty"`
 	// CreatedAt is the timestamp when the scan result was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the scan result was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// UnmarshalJSON decodes a scan result, keeping unknown fields in Extra.
+func (s *ScanResult) UnmarshalJSON(data []byte) error {
+	type scanResult ScanResult
+	return api.UnmarshalWithExtra(data, (*scanResult)(s), &s.Extra)
+}
+
+// MarshalJSON encodes a scan result, including the fields in Extra.
+func (s ScanResult) MarshalJSON() ([]byte, error) {
+	type scanResult ScanResult
+	return api.MarshalWithExtra(scanResult(s), s.Extra)
+}
+
+// Fields of ScanResult, for filtering, sorting and selecting with
+// ScanResultsAPI.Where, SortBy and Fields.
+var (
+	ScanResultID               = api.NewField[ScanResult]("id")
+	ScanResultScanTaskID       = api.NewField[ScanResult]("scan_task_id")
+	ScanResultScanObjectID     = api.NewField[ScanResult]("scanobject_id")
+	ScanResultTemplate         = api.NewField[ScanResult]("template")
+	ScanResultTemplateID       = api.NewField[ScanResult]("template_id")
+	ScanResultMatcherName      = api.NewField[ScanResult]("matcher_name")
+	ScanResultExtractorName    = api.NewField[ScanResult]("extractor_name")
+	ScanResultType             = api.NewField[ScanResult]("type")
+	ScanResultHost             = api.NewField[ScanResult]("host")
+	ScanResultPort             = api.NewField[ScanResult]("port")
+	ScanResultScheme           = api.NewField[ScanResult]("scheme")
+	ScanResultURL              = api.NewField[ScanResult]("url")
+	ScanResultPath             = api.NewField[ScanResult]("path")
+	ScanResultMatchedAt        = api.NewField[ScanResult]("matched_at")
+	ScanResultIP               = api.NewField[ScanResult]("ip")
+	ScanResultTimestamp        = api.NewField[ScanResult]("timestamp")
+	ScanResultMatcherStatus    = api.NewField[ScanResult]("matcher_status")
+	ScanResultReqURLPattern    = api.NewField[ScanResult]("req_url_pattern")
+	ScanResultIsFuzzingResult  = api.NewField[ScanResult]("is_fuzzing_result")
+	ScanResultFuzzingMethod    = api.NewField[ScanResult]("fuzzing_method")
+	ScanResultFuzzingParameter = api.NewField[ScanResult]("fuzzing_parameter")
+	ScanResultFuzzingPosition  = api.NewField[ScanResult]("fuzzing_position")
+	ScanResultError            = api.NewField[ScanResult]("error")
+	ScanResultCreatedAt        = api.NewField[ScanResult]("created_at")
+	ScanResultUpdatedAt        = api.NewField[ScanResult]("updated_at")
+)
+
 // ScanResultsAPI is the API for the scan results resource.
 type ScanResultsAPI struct {
-	api.APIRequestHandler
+	api.Collection[ScanResult]
 }
 
 // ScanResultsAPIResponse is the response structure for the scan results API.
-type ScanResultsAPIResponse struct {
-	// Data contains the list of scan results.
-	Data []ScanResult `json:"data"`
-	// Links contains pagination and other links.
-	Links APIResponseLinks `json:"links,omitempty"`
-	// Meta contains metadata about the response.
-	Meta APIResponseMeta `json:"meta,omitempty"`
-}
+type ScanResultsAPIResponse = api.List[ScanResult]
 
 // NewScanResultsAPI creates a new ScanResultsAPI instance.
 func NewScanResultsAPI(c *client.Client) *ScanResultsAPI {
-	return &ScanResultsAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/scan-results",
-		},
-	}
-}
-
-// Get retrieves a list of scan results.
-func (s *ScanResultsAPI) Get() (*ScanResultsAPIResponse, error) {
-	return api.Do[ScanResultsAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
+	return &ScanResultsAPI{Collection: *api.NewCollection[ScanResult](c, c.BaseURL+"/api/v2/scan-results")}
 }
 
 // Upsert creates or updates a scan result.
@@ -128,57 +158,73 @@ func (s *ScanResultsAPI) Upsert(data api.APIRequestPayload) (*ScanResultAPIRespo
 	return api.Do[ScanResultAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), data)
 }
 
-// Page sets the page number for pagination.
-func (p *ScanResultsAPI) Page(page int) *ScanResultsAPI {
-	p.SetParam("page", fmt.Sprintf("%d", page))
-	return p
+// ScanResultAPI is the API for a single scan result instance.
+type ScanResultAPI struct {
+	api.Item[ScanResult]
 }
 
-// PerPage sets the number of items per page for pagination.
-func (p *ScanResultsAPI) PerPage(perPage int) *ScanResultsAPI {
-	p.SetParam("per_page", fmt.Sprintf("%d", perPage))
-	return p
-}
+// ScanResultAPIResponse is the response structure for a single scan result
+// API.
+type ScanResultAPIResponse = api.Response[ScanResult]
 
-// Scopes sets the scopes to filter by.
-func (p *ScanResultsAPI) Scopes(scopes ...string) *ScanResultsAPI {
-	p.SetParam("scopes", strings.Join(scopes, ","))
-	return p
+// NewScanResultAPI creates a new ScanResultAPI instance for a specific scan
+// result.
+func NewScanResultAPI(c *client.Client, id string) *ScanResultAPI {
+	return &ScanResultAPI{Item: *api.NewItem[ScanResult](c, c.BaseURL+"/api/v2/scan-results/"+id, id)}
 }
 
-// Sort sets the sorting key and order.
-func (p *ScanResultsAPI) Sort(sort, order string) *ScanResultsAPI {
-	p.SetParam("sort", sort+","+order)
-	return p
+// ScanResultSummary is a lightweight projection of ScanResult without the raw
+// request, response and template, for large listings. The full record can be
+// fetched with ScanResult(id).Get().
+type ScanResultSummary struct {
+	// ID is the unique identifier for the scan result.
+	ID string `json:"id"`
+	// Template is the template used for the scan result.
+	Template string `json:"template"`
+	// TemplateID is the ID of the template used for the scan result.
+	TemplateID string `json:"template_id"`
+	// Info is the metadata about the scan result, such as its name and
+	// severity.
+	Info map[string]interface{} `json:"info"`
+	// Type is the type of the scan result (e.g., "tcp", "http", "mongodb").
+	Type string `json:"type"`
+	// Host is the hostname or IP address of the scan result.
+	Host string `json:"host"`
+	// Port is the port number associated with the scan result.
+	Port api.FlexString `json:"port"`
+	// URL is the URL of the scan result, if applicable.
+	URL string `json:"url"`
+	// MatchedAt is the timestamp when the scan result was matched.
+	MatchedAt api.Time `json:"matched_at"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-// ScanResultAPI is the API for a single scan result instance.
-type ScanResultAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the scan result.
-	ID string
+// ScanResultSummariesResponse is the response structure for a page of scan
+// result summaries.
+type ScanResultSummariesResponse = api.List[ScanResultSummary]
+
+// UnmarshalJSON decodes a scan result summary, keeping unknown fields in
+// Extra.
+func (s *ScanResultSummary) UnmarshalJSON(data []byte) error {
+	type scanResultSummary ScanResultSummary
+	return api.UnmarshalWithExtra(data, (*scanResultSummary)(s), &s.Extra)
 }
 
-// ScanResultAPIResponse is the response structure for a single scan result
-// API.
-type ScanResultAPIResponse struct {
-	// Data contains the scan result.
-	Data ScanResult `json:"data"`
+// MarshalJSON encodes a scan result summary, including the fields in Extra.
+func (s ScanResultSummary) MarshalJSON() ([]byte, error) {
+	type scanResultSummary ScanResultSummary
+	return api.MarshalWithExtra(scanResultSummary(s), s.Extra)
 }
 
-// NewScanResultAPI creates a new ScanResultAPI instance for a specific scan
-// result.
-func NewScanResultAPI(c *client.Client, id string) *ScanResultAPI {
-	return &ScanResultAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/scan-results/" + id,
-		},
-		ID: id,
-	}
+// Severity returns the severity from the template info, such as "high".
+func (s ScanResultSummary) Severity() string {
+	severity, _ := s.Info["severity"].(string)
+	return severity
 }
 
-// Get retrieves a single scan result by its ID.
-func (s *ScanResultAPI) Get() (*ScanResultAPIResponse, error) {
-	return api.Do[ScanResultAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
+// Summaries retrieves a page of scan result summaries, requesting only the
+// fields ScanResultSummary declares.
+func (s *ScanResultsAPI) Summaries() (*ScanResultSummariesResponse, error) {
+	return api.GetAs[ScanResultSummary](s)
 }
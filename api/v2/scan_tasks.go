@@ -1,8 +1,8 @@
 package v2
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
+	"sort"
 
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
@@ -20,7 +20,7 @@ type ScanTask struct {
 	// ProbeID is the ID of the probe associated with the scan task.
 	ProbeID string `json:"probe_id"`
 	// Type is the type of the scan task (0 for scheduled, 1 for rescan).
-	Type string `json:"type"`
+	Type api.ScanTaskType `json:"type"`
 	// Company is the company that owns the scan task, included via ?with=company.
 	Company *Company `json:"company,omitempty"`
 	// ScannerPlatform is the scanner platform associated with the scan task, included via ?with=scannerplatform.
@@ -30,129 +30,89 @@ type ScanTask struct {
 	// RescanTargets contains the rescan targets, included via ?with=rescan-targets.
 	RescanTargets []RescanTarget `json:"rescanTargets,omitempty"`
 	// StartedAt is the timestamp when the scan task was started.
-	StartedAt string `json:"started_at"`
+	StartedAt api.Time `json:"started_at"`
 	// StoppedAt is the timestamp when the scan task was stopped, if applicable.
-	StoppedAt string `json:"stopped_at,omitempty"`
+	StoppedAt api.Time `json:"stopped_at,omitzero"`
 	// CreatedAt is the timestamp when the scan task was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the scan task was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
 	// Error contains the error message if the scan failed.
 	Error string `json:"error,omitempty"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-// RescanTarget represents a target for a rescan operation, included as a
-// relationship on ScanTask via ?with=rescan-targets.
-type RescanTarget struct {
-	ID           int    `json:"id"`
-	ScanObjectID string `json:"scanobject_id"`
-	Target       string `json:"target"`
-	Template     string `json:"template"`
-	CreatedAt    string `json:"created_at"`
-	UpdatedAt    string `json:"updated_at"`
+// UnmarshalJSON decodes a scan task, keeping unknown fields in Extra.
+func (s *ScanTask) UnmarshalJSON(data []byte) error {
+	type scanTask ScanTask
+	return api.UnmarshalWithExtra(data, (*scanTask)(s), &s.Extra)
 }
 
-// ScanTasksAPI is the API for the scan tasks resource.
-type ScanTasksAPI struct {
-	api.APIRequestHandler
-}
-
-// ScanTasksAPIResponse is the response structure for the scan tasks API.
-type ScanTasksAPIResponse struct {
-	// Data contains the list of scan tasks.
-	Data []ScanTask `json:"data"`
-	// Links contains pagination and other links.
-	Links APIResponseLinks `json:"links,omitempty"`
-	// Meta contains metadata about the response.
-	Meta APIResponseMeta `json:"meta,omitempty"`
-}
-
-// NewScanTasksAPI creates a new ScanTasksAPI instance.
-func NewScanTasksAPI(c *client.Client) *ScanTasksAPI {
-	return &ScanTasksAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/scan-tasks",
-		},
-	}
-}
-
-// Get retrieves a list of scan tasks.
-func (s *ScanTasksAPI) Get() (*ScanTasksAPIResponse, error) {
-	return api.Do[ScanTasksAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
+// MarshalJSON encodes a scan task, including the fields in Extra.
+func (s ScanTask) MarshalJSON() ([]byte, error) {
+	type scanTask ScanTask
+	return api.MarshalWithExtra(scanTask(s), s.Extra)
 }
 
-// Create creates a scan task.
-func (s *ScanTasksAPI) Create(data api.APIRequestPayload) (*ScanTaskAPIResponse, error) {
-	return api.Do[ScanTaskAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), data)
+// IsRescan reports whether the scan task is a rescan rather than a
+// scheduled scan.
+func (t ScanTask) IsRescan() bool {
+	return t.Type == api.ScanTaskTypeRescan
 }
 
-// Page sets the page number for pagination.
-func (p *ScanTasksAPI) Page(page int) *ScanTasksAPI {
-	p.SetParam("page", fmt.Sprintf("%d", page))
-	return p
+// State returns the lifecycle state of the scan task, derived from StartedAt,
+// StoppedAt and Error.
+func (t ScanTask) State() api.ScanTaskState {
+	return api.NewScanTaskState(t.StartedAt, t.StoppedAt, t.Error)
 }
 
-// PerPage sets the number of items per page for pagination.
-func (p *ScanTasksAPI) PerPage(perPage int) *ScanTasksAPI {
-	p.SetParam("per_page", fmt.Sprintf("%d", perPage))
-	return p
-}
+// Fields of ScanTask, for filtering, sorting and selecting with
+// ScanTasksAPI.Where, SortBy and Fields.
+var (
+	ScanTaskID                = api.NewField[ScanTask]("id")
+	ScanTaskCompanyID         = api.NewField[ScanTask]("company_id")
+	ScanTaskScannerPlatformID = api.NewField[ScanTask]("scannerplatform_id")
+	ScanTaskProbeID           = api.NewField[ScanTask]("probe_id")
+	ScanTaskType              = api.NewField[ScanTask]("type")
+	ScanTaskStartedAt         = api.NewField[ScanTask]("started_at")
+	ScanTaskStoppedAt         = api.NewField[ScanTask]("stopped_at")
+	ScanTaskCreatedAt         = api.NewField[ScanTask]("created_at")
+	ScanTaskUpdatedAt         = api.NewField[ScanTask]("updated_at")
+	ScanTaskError             = api.NewField[ScanTask]("error")
+)
 
-// Scopes sets the scopes to filter by.
-func (p *ScanTasksAPI) Scopes(scopes ...string) *ScanTasksAPI {
-	p.SetParam("scopes", strings.Join(scopes, ","))
-	return p
+// ScanTasksAPI is the API for the scan tasks resource.
+type ScanTasksAPI struct {
+	api.Collection[ScanTask]
 }
 
-// With sets the relationships to include in the response.
-func (p *ScanTasksAPI) With(relationships ...string) *ScanTasksAPI {
-	p.SetParam("with", strings.Join(relationships, ","))
-	return p
-}
+// ScanTasksAPIResponse is the response structure for the scan tasks API.
+type ScanTasksAPIResponse = api.List[ScanTask]
 
-// Sort sets the sorting key and order.
-func (p *ScanTasksAPI) Sort(sort, order string) *ScanTasksAPI {
-	p.SetParam("sort", sort+","+order)
-	return p
+// NewScanTasksAPI creates a new ScanTasksAPI instance.
+func NewScanTasksAPI(c *client.Client) *ScanTasksAPI {
+	s := &ScanTasksAPI{Collection: *api.NewCollection[ScanTask](c, c.BaseURL+"/api/v2/scan-tasks")}
+	s.UpdateMethod = "PATCH"
+	return s
 }
 
 // ScanTaskAPI is the API for a specific scan task.
 type ScanTaskAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the scan task.
-	ID string
+	api.Item[ScanTask]
 }
 
 // ScanTaskAPIResponse is the response structure for a single scan task.
-type ScanTaskAPIResponse struct {
-	// Data contains the scan task details.
-	Data ScanTask `json:"data"`
-}
+type ScanTaskAPIResponse = api.Response[ScanTask]
 
 // NewScanTaskAPI creates a new ScanTaskAPI instance for a specific scan
 // task ID.
 func NewScanTaskAPI(c *client.Client, id string) *ScanTaskAPI {
-	return &ScanTaskAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/scan-tasks/" + id,
-		},
-		ID: id,
-	}
-}
-
-// With sets the relationships to include in the response.
-func (s *ScanTaskAPI) With(relationships ...string) *ScanTaskAPI {
-	s.SetParam("with", strings.Join(relationships, ","))
+	s := &ScanTaskAPI{Item: *api.NewItem[ScanTask](c, c.BaseURL+"/api/v2/scan-tasks/"+id, id)}
+	s.UpdateMethod = "PATCH"
 	return s
 }
 
-// Get retrieves a single scan task.
-func (s *ScanTaskAPI) Get() (*ScanTaskAPIResponse, error) {
-	return api.Do[ScanTaskAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
 // Start starts a scan task.
 func (s *ScanTaskAPI) Start() (*ScanTaskAPIResponse, error) {
 	s.BaseURL = s.BaseURL + "/start"
@@ -165,16 +125,104 @@ func (s *ScanTaskAPI) Stop() (*ScanTaskAPIResponse, error) {
 	return api.Do[ScanTaskAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), nil)
 }
 
-// Update updates a scan task with the given payload.
-func (s *ScanTaskAPI) Update(data api.APIRequestPayload) (*ScanTaskAPIResponse, error) {
-	return api.Do[ScanTaskAPIResponse](s.APIRequestHandler, "PATCH", s.BuildURL(), data)
+// ScanObjectsChange reports how a call changed the scan objects of a scan
+// task.
+type ScanObjectsChange struct {
+	// ScanTaskID is the ID of the scan task.
+	ScanTaskID string
+	// Associated contains the IDs of the scan objects associated with the
+	// scan task.
+	Associated []string
+	// Disassociated contains the IDs of the scan objects no longer
+	// associated with the scan task.
+	Disassociated []string
+}
+
+// Empty reports whether the change neither associated nor disassociated
+// scan objects.
+func (c ScanObjectsChange) Empty() bool {
+	return len(c.Associated) == 0 && len(c.Disassociated) == 0
+}
+
+// ScanObjects retrieves the scan objects a scan task covers.
+func (s *ScanTaskAPI) ScanObjects() *ScanObjectsAPI {
+	scanObjectsAPI := NewScanObjectsAPI(s.Client)
+	scanObjectsAPI.BaseURL = s.BaseURL + "/scanobjects"
+	return scanObjectsAPI
 }
 
 // AssociateScanObjects associates scan objects with a scan task.
-func (s *ScanTaskAPI) AssociateScanObjects(ids []string) (*ScanTaskAPIResponse, error) {
-	s.BaseURL = s.BaseURL + "/scanobjects"
-	payload := api.APIRequestPayload{"ids": ids}
-	return api.Do[ScanTaskAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), payload)
+func (s *ScanTaskAPI) AssociateScanObjects(ids []string) (*ScanObjectsChange, error) {
+	if err := s.changeScanObjects("POST", ids); err != nil {
+		return nil, err
+	}
+	return &ScanObjectsChange{ScanTaskID: s.ID, Associated: ids}, nil
+}
+
+// DisassociateScanObjects removes scan objects from a scan task.
+func (s *ScanTaskAPI) DisassociateScanObjects(ids []string) (*ScanObjectsChange, error) {
+	if err := s.changeScanObjects("DELETE", ids); err != nil {
+		return nil, err
+	}
+	return &ScanObjectsChange{ScanTaskID: s.ID, Disassociated: ids}, nil
+}
+
+// SyncScanObjects makes ids the scan objects of a scan task. It lists the
+// scan objects the task covers, then associates the missing ones and
+// disassociates the others. If associating or disassociating fails, the
+// error is returned with the change made so far: an empty change if
+// associating failed, or the associated IDs if disassociating failed.
+func (s *ScanTaskAPI) SyncScanObjects(ids []string) (*ScanObjectsChange, error) {
+	current := map[string]bool{}
+	for page := 1; ; page++ {
+		resp, err := s.ScanObjects().Page(page).PerPage(100).Get()
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range resp.Data {
+			current[o.ID] = true
+		}
+		if page >= resp.Meta.LastPage {
+			break
+		}
+	}
+
+	change := &ScanObjectsChange{ScanTaskID: s.ID}
+	wanted := map[string]bool{}
+	for _, id := range ids {
+		if !wanted[id] && !current[id] {
+			change.Associated = append(change.Associated, id)
+		}
+		wanted[id] = true
+	}
+	for id := range current {
+		if !wanted[id] {
+			change.Disassociated = append(change.Disassociated, id)
+		}
+	}
+	sort.Strings(change.Disassociated)
+
+	if len(change.Associated) > 0 {
+		if err := s.changeScanObjects("POST", change.Associated); err != nil {
+			return &ScanObjectsChange{ScanTaskID: s.ID}, err
+		}
+	}
+	if len(change.Disassociated) > 0 {
+		if err := s.changeScanObjects("DELETE", change.Disassociated); err != nil {
+			change.Disassociated = nil
+			return change, err
+		}
+	}
+	return change, nil
+}
+
+// changeScanObjects posts or deletes scan object IDs on the scan objects of
+// a scan task.
+func (s *ScanTaskAPI) changeScanObjects(method string, ids []string) error {
+	h := s.APIRequestHandler
+	h.BaseURL += "/scanobjects"
+	_, err := api.Do[ScanTaskAPIResponse](h, method, h.BuildURL(), api.APIRequestPayload{"ids": ids})
+	return err
 }
 
 // HostDiscoveries retrieves the host discoveries for a scan task.
@@ -0,0 +1,132 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scanObjectsServer serves the scan objects of a scan task in pages of two
+// and records the IDs posted to and deleted from them.
+type scanObjectsServer struct {
+	mu            sync.Mutex
+	current       []string
+	failList      bool
+	failDelete    bool
+	associated    [][]string
+	disassociated [][]string
+}
+
+func (s *scanObjectsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.URL.Path != "/api/v2/scan-tasks/t1/scanobjects" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		if s.failList {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Forbidden"}`))
+			return
+		}
+		page := 1
+		_, _ = fmt.Sscan(r.URL.Query().Get("page"), &page)
+		var data []ScanObject
+		for i := (page - 1) * 2; i < len(s.current) && i < page*2; i++ {
+			data = append(data, ScanObject{ID: s.current[i]})
+		}
+		lastPage := max(1, (len(s.current)+1)/2)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": data,
+			"meta": map[string]interface{}{"current_page": page, "last_page": lastPage},
+		})
+	case http.MethodPost, http.MethodDelete:
+		if r.Method == http.MethodDelete && s.failDelete {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Forbidden"}`))
+			return
+		}
+		var body struct {
+			IDs []string `json:"ids"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.Method == http.MethodPost {
+			s.associated = append(s.associated, body.IDs)
+		} else {
+			s.disassociated = append(s.disassociated, body.IDs)
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":"t1"}}`))
+	}
+}
+
+func TestScanTaskAPI_SyncScanObjects(t *testing.T) {
+	srv := &scanObjectsServer{current: []string{"a", "b", "c"}}
+	lh := New(newTestClient(t, srv.ServeHTTP))
+
+	change, err := lh.ScanTask("t1").SyncScanObjects([]string{"b", "d", "d", "e"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "t1", change.ScanTaskID)
+	assert.Equal(t, []string{"d", "e"}, change.Associated)
+	assert.Equal(t, []string{"a", "c"}, change.Disassociated)
+	assert.Equal(t, [][]string{{"d", "e"}}, srv.associated)
+	assert.Equal(t, [][]string{{"a", "c"}}, srv.disassociated)
+}
+
+func TestScanTaskAPI_SyncScanObjects_Unchanged(t *testing.T) {
+	srv := &scanObjectsServer{current: []string{"a", "b"}}
+	lh := New(newTestClient(t, srv.ServeHTTP))
+
+	change, err := lh.ScanTask("t1").SyncScanObjects([]string{"b", "a"})
+	require.NoError(t, err)
+
+	assert.True(t, change.Empty())
+	assert.Empty(t, srv.associated)
+	assert.Empty(t, srv.disassociated)
+}
+
+func TestScanTaskAPI_SyncScanObjects_ListFails(t *testing.T) {
+	srv := &scanObjectsServer{current: []string{"a"}, failList: true}
+	lh := New(newTestClient(t, srv.ServeHTTP))
+
+	_, err := lh.ScanTask("t1").SyncScanObjects([]string{"b"})
+	require.Error(t, err)
+
+	assert.Empty(t, srv.associated)
+	assert.Empty(t, srv.disassociated)
+}
+
+func TestScanTaskAPI_SyncScanObjects_DisassociateFails(t *testing.T) {
+	srv := &scanObjectsServer{current: []string{"a"}, failDelete: true}
+	lh := New(newTestClient(t, srv.ServeHTTP))
+
+	change, err := lh.ScanTask("t1").SyncScanObjects([]string{"b"})
+	require.Error(t, err)
+
+	require.NotNil(t, change)
+	assert.Equal(t, []string{"b"}, change.Associated)
+	assert.Empty(t, change.Disassociated)
+	assert.Equal(t, [][]string{{"b"}}, srv.associated)
+}
+
+func TestScanTasksAPI_UpdateManyPatches(t *testing.T) {
+	var method string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"id":"t1"}}`))
+	})
+
+	_, err := New(c).ScanTasks().UpdateMany([]api.BulkUpdate{{ID: "t1", Data: api.APIRequestPayload{"error": ""}}}, api.BulkOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, method)
+}
@@ -1,8 +1,7 @@
 package v2
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
 
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
@@ -13,7 +12,7 @@ type ScannerPlatform struct {
 	// ID is the unique identifier for the scanner platform.
 	ID string `json:"id"`
 	// Type is the type of scanner platform ("public" or "private").
-	Type string `json:"type"`
+	Type api.ScannerPlatformType `json:"type"`
 	// Name is the name of the scanner platform.
 	Name string `json:"name"`
 	// Endpoint is the endpoint URL for the scanner platform.
@@ -25,118 +24,66 @@ type ScannerPlatform struct {
 	// ?with=probe.
 	Probe *Probe `json:"probe,omitempty"`
 	// CreatedAt is the timestamp when the scanner platform was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the scanner platform was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
 	// DeletedAt is the timestamp when the scanner platform was deleted, if
 	// applicable.
-	DeletedAt string `json:"deleted_at,omitempty"`
+	DeletedAt api.Time `json:"deleted_at,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// UnmarshalJSON decodes a scanner platform, keeping unknown fields in Extra.
+func (s *ScannerPlatform) UnmarshalJSON(data []byte) error {
+	type scannerPlatform ScannerPlatform
+	return api.UnmarshalWithExtra(data, (*scannerPlatform)(s), &s.Extra)
+}
+
+// MarshalJSON encodes a scanner platform, including the fields in Extra.
+func (s ScannerPlatform) MarshalJSON() ([]byte, error) {
+	type scannerPlatform ScannerPlatform
+	return api.MarshalWithExtra(scannerPlatform(s), s.Extra)
+}
+
+// Fields of ScannerPlatform, for filtering, sorting and selecting with
+// ScannerPlatformsAPI.Where, SortBy and Fields.
+var (
+	ScannerPlatformID        = api.NewField[ScannerPlatform]("id")
+	ScannerPlatformType      = api.NewField[ScannerPlatform]("type")
+	ScannerPlatformName      = api.NewField[ScannerPlatform]("name")
+	ScannerPlatformEndpoint  = api.NewField[ScannerPlatform]("endpoint")
+	ScannerPlatformCreatedAt = api.NewField[ScannerPlatform]("created_at")
+	ScannerPlatformUpdatedAt = api.NewField[ScannerPlatform]("updated_at")
+	ScannerPlatformDeletedAt = api.NewField[ScannerPlatform]("deleted_at")
+)
+
 // ScannerPlatformsAPI is the API for the scanner platforms resource.
 type ScannerPlatformsAPI struct {
-	api.APIRequestHandler
+	api.Collection[ScannerPlatform]
 }
 
 // ScannerPlatformsAPIResponse is the response structure for the scanner
 // platforms API.
-type ScannerPlatformsAPIResponse struct {
-	// Data contains the list of scanner platforms.
-	Data []ScannerPlatform `json:"data"`
-	// Links contains pagination and other links.
-	Links APIResponseLinks `json:"links,omitempty"`
-	// Meta contains metadata about the response.
-	Meta APIResponseMeta `json:"meta,omitempty"`
-}
+type ScannerPlatformsAPIResponse = api.List[ScannerPlatform]
 
 // NewScannerPlatformsAPI creates a new ScannerPlatformsAPI instance.
 func NewScannerPlatformsAPI(c *client.Client) *ScannerPlatformsAPI {
-	return &ScannerPlatformsAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/scannerplatforms",
-		},
-	}
-}
-
-// Get retrieves a list of scanner platforms.
-func (s *ScannerPlatformsAPI) Get() (*ScannerPlatformsAPIResponse, error) {
-	return api.Do[ScannerPlatformsAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Create creates a new scanner platform.
-func (s *ScannerPlatformsAPI) Create(data api.APIRequestPayload) (*ScannerPlatformAPIResponse, error) {
-	return api.Do[ScannerPlatformAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), data)
-}
-
-// Page sets the page number for pagination.
-func (s *ScannerPlatformsAPI) Page(page int) *ScannerPlatformsAPI {
-	s.SetParam("page", fmt.Sprintf("%d", page))
-	return s
-}
-
-// PerPage sets the number of items per page for pagination.
-func (s *ScannerPlatformsAPI) PerPage(perPage int) *ScannerPlatformsAPI {
-	s.SetParam("per_page", fmt.Sprintf("%d", perPage))
-	return s
-}
-
-// Scopes sets the scopes to filter by.
-func (s *ScannerPlatformsAPI) Scopes(scopes ...string) *ScannerPlatformsAPI {
-	s.SetParam("scopes", strings.Join(scopes, ","))
-	return s
-}
-
-// Sort sets the sorting key and order.
-func (s *ScannerPlatformsAPI) Sort(sort, order string) *ScannerPlatformsAPI {
-	s.SetParam("sort", sort+","+order)
-	return s
+	return &ScannerPlatformsAPI{Collection: *api.NewCollection[ScannerPlatform](c, c.BaseURL+"/api/v2/scannerplatforms")}
 }
 
 // ScannerPlatformAPI is the API for a single scanner platform instance.
 type ScannerPlatformAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the scanner platform.
-	ID string
+	api.Item[ScannerPlatform]
 }
 
 // ScannerPlatformAPIResponse is the response structure for a single scanner
 // platform.
-type ScannerPlatformAPIResponse struct {
-	// Data contains the scanner platform details.
-	Data ScannerPlatform `json:"data"`
-}
+type ScannerPlatformAPIResponse = api.Response[ScannerPlatform]
 
 // NewScannerPlatformAPI creates a new ScannerPlatformAPI instance.
 func NewScannerPlatformAPI(c *client.Client, id string) *ScannerPlatformAPI {
-	return &ScannerPlatformAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/scannerplatforms/" + id,
-		},
-		ID: id,
-	}
-}
-
-// Get retrieves a single scanner platform by ID.
-func (s *ScannerPlatformAPI) Get() (*ScannerPlatformAPIResponse, error) {
-	return api.Do[ScannerPlatformAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Update updates a scanner platform.
-func (s *ScannerPlatformAPI) Update(data api.APIRequestPayload) (*ScannerPlatformAPIResponse, error) {
-	return api.Do[ScannerPlatformAPIResponse](s.APIRequestHandler, "PUT", s.BuildURL(), data)
-}
-
-// Delete deletes a scanner platform.
-func (s *ScannerPlatformAPI) Delete() (*ScannerPlatformAPIResponse, error) {
-	return api.Do[ScannerPlatformAPIResponse](s.APIRequestHandler, "DELETE", s.BuildURL(), nil)
-}
-
-// With sets the relationships to include in the response.
-func (s *ScannerPlatformAPI) With(relationships ...string) *ScannerPlatformAPI {
-	s.SetParam("with", strings.Join(relationships, ","))
-	return s
+	return &ScannerPlatformAPI{Item: *api.NewItem[ScannerPlatform](c, c.BaseURL+"/api/v2/scannerplatforms/"+id, id)}
 }
 
 // Schedules retrieves the schedules for a scanner platform.
@@ -158,4 +105,4 @@ func (s *ScannerPlatformAPI) ScanTasks() *ScanTasksAPI {
 	scanTasksAPI := NewScanTasksAPI(s.Client)
 	scanTasksAPI.BaseURL = s.BaseURL + "/scan-tasks"
 	return scanTasksAPI
-}
\ No newline at end of file
+}
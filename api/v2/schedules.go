@@ -1,8 +1,7 @@
 package v2
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
 
 	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
@@ -21,118 +20,82 @@ type Schedule struct {
 	// To is the end time of the schedule in HH:MM format.
 	To string `json:"to"`
 	// Active indicates whether the schedule is active or not.
-	Active bool `json:"active"`
+	Active api.FlexBool `json:"active"`
 	// Company is the company that owns the schedule. Included via
 	// ?with=company.
 	Company *Company `json:"company,omitempty"`
 	// CreatedAt is the timestamp when the schedule was created.
-	CreatedAt string `json:"created_at"`
+	CreatedAt api.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the schedule was last updated.
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt api.Time `json:"updated_at"`
 	// DeletedAt is the timestamp when the schedule was deleted, if applicable.
-	DeletedAt string `json:"deleted_at,omitempty"`
-}
+	DeletedAt api.Time `json:"deleted_at,omitzero"`
+	// Extra holds response fields not declared above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a schedule, keeping unknown fields in Extra.
+func (s *Schedule) UnmarshalJSON(data []byte) error {
+	type schedule Schedule
+	return api.UnmarshalWithExtra(data, (*schedule)(s), &s.Extra)
+}
+
+// MarshalJSON encodes a schedule, including the fields in Extra.
+func (s Schedule) MarshalJSON() ([]byte, error) {
+	type schedule Schedule
+	return api.MarshalWithExtra(schedule(s), s.Extra)
+}
+
+// Fields of Schedule, for filtering, sorting and selecting with
+// SchedulesAPI.Where, SortBy and Fields.
+var (
+	ScheduleID          = api.NewField[Schedule]("id")
+	ScheduleName        = api.NewField[Schedule]("name")
+	ScheduleDescription = api.NewField[Schedule]("description")
+	ScheduleFrom        = api.NewField[Schedule]("from")
+	ScheduleTo          = api.NewField[Schedule]("to")
+	ScheduleActive      = api.NewField[Schedule]("active")
+	ScheduleCreatedAt   = api.NewField[Schedule]("created_at")
+	ScheduleUpdatedAt   = api.NewField[Schedule]("updated_at")
+	ScheduleDeletedAt   = api.NewField[Schedule]("deleted_at")
+)
 
 // SchedulesAPI is the API for the schedules resource.
 type SchedulesAPI struct {
-	api.APIRequestHandler
+	api.Collection[Schedule]
 }
 
 // SchedulesAPIResponse is the response structure for the schedules API.
-type SchedulesAPIResponse struct {
-	// Data contains the list of schedules.
-	Data []Schedule `json:"data"`
-	// Links contains pagination and other links.
-	Links APIResponseLinks `json:"links,omitempty"`
-	// Meta contains metadata about the response.
-	Meta APIResponseMeta `json:"meta,omitempty"`
-}
+type SchedulesAPIResponse = api.List[Schedule]
 
 // NewSchedulesAPI creates a new SchedulesAPI instance.
 func NewSchedulesAPI(c *client.Client) *SchedulesAPI {
-	return &SchedulesAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/schedules",
-		},
-	}
-}
-
-// Get retrieves a list of schedules.
-func (s *SchedulesAPI) Get() (*SchedulesAPIResponse, error) {
-	return api.Do[SchedulesAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
-}
-
-// Create creates a new schedule.
-func (s *SchedulesAPI) Create(data api.APIRequestPayload) (*ScheduleAPIResponse, error) {
-	return api.Do[ScheduleAPIResponse](s.APIRequestHandler, "POST", s.BuildURL(), data)
-}
-
-// Page sets the page number for pagination.
-func (p *SchedulesAPI) Page(page int) *SchedulesAPI {
-	p.SetParam("page", fmt.Sprintf("%d", page))
-	return p
-}
-
-// PerPage sets the number of items per page for pagination.
-func (p *SchedulesAPI) PerPage(perPage int) *SchedulesAPI {
-	p.SetParam("per_page", fmt.Sprintf("%d", perPage))
-	return p
-}
-
-// Scopes sets the scopes to filter by.
-func (p *SchedulesAPI) Scopes(scopes ...string) *SchedulesAPI {
-	p.SetParam("scopes", strings.Join(scopes, ","))
-	return p
-}
-
-// With sets the relationships to include in the response.
-func (p *SchedulesAPI) With(relationships ...string) *SchedulesAPI {
-	p.SetParam("with", strings.Join(relationships, ","))
-	return p
-}
-
-// Sort sets the sorting key and order.
-func (p *SchedulesAPI) Sort(sort, order string) *SchedulesAPI {
-	p.SetParam("sort", sort+","+order)
-	return p
+	return &SchedulesAPI{Collection: *api.NewCollection[Schedule](c, c.BaseURL+"/api/v2/schedules")}
 }
 
 // ScheduleAPI is the API for a specific schedule.
 type ScheduleAPI struct {
-	api.APIRequestHandler
-	// ID is the unique identifier for the schedule.
-	ID string
+	api.Item[Schedule]
 }
 
 // ScheduleAPIResponse is the response structure for a specific schedule.
-type ScheduleAPIResponse struct {
-	// Data contains the schedule details.
-	Data Schedule `json:"data"`
-}
+type ScheduleAPIResponse = api.Response[Schedule]
 
 // NewScheduleAPI creates a new ScheduleAPI instance for a specific schedule.
 func NewScheduleAPI(c *client.Client, id string) *ScheduleAPI {
-	return &ScheduleAPI{
-		APIRequestHandler: api.APIRequestHandler{
-			Client:  c,
-			BaseURL: c.BaseURL + "/api/v2/schedules/" + id,
-		},
-		ID: id,
-	}
-}
-
-// Get retrieves a specific schedule by its ID.
-func (s *ScheduleAPI) Get() (*ScheduleAPIResponse, error) {
-	return api.Do[ScheduleAPIResponse](s.APIRequestHandler, "GET", s.BuildURL(), nil)
+	return &ScheduleAPI{Item: *api.NewItem[Schedule](c, c.BaseURL+"/api/v2/schedules/"+id, id)}
 }
 
-// Update updates an existing schedule.
-func (s *ScheduleAPI) Update(data api.APIRequestPayload) (*ScheduleAPIResponse, error) {
-	return api.Do[ScheduleAPIResponse](s.APIRequestHandler, "PUT", s.BuildURL(), data)
+// Probes retrieves the probes that run a schedule.
+func (s *ScheduleAPI) Probes() *ProbesAPI {
+	probesAPI := NewProbesAPI(s.Client)
+	probesAPI.BaseURL = s.BaseURL + "/probes"
+	return probesAPI
 }
 
-// Delete deletes a specific schedule by its ID.
-func (s *ScheduleAPI) Delete() (*ScheduleAPIResponse, error) {
-	return api.Do[ScheduleAPIResponse](s.APIRequestHandler, "DELETE", s.BuildURL(), nil)
+// ScanTasks retrieves the scan tasks started by a schedule.
+func (s *ScheduleAPI) ScanTasks() *ScanTasksAPI {
+	scanTasksAPI := NewScanTasksAPI(s.Client)
+	scanTasksAPI.BaseURL = s.BaseURL + "/scan-tasks"
+	return scanTasksAPI
 }
@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"github.com/guardian360/go-lighthouse/api"
 	"github.com/guardian360/go-lighthouse/client"
 )
 
@@ -80,6 +81,42 @@ func (api *API) ScannerPlatform(id string) *ScannerPlatformAPI {
 	return NewScannerPlatformAPI(api.Client, id)
 }
 
+// ScanObjects retrieves the scan objects API.
+func (api *API) ScanObjects() *ScanObjectsAPI {
+	return NewScanObjectsAPI(api.Client)
+}
+
+// ScanObject retrieves the scan object API for a specific ID.
+func (api *API) ScanObject(id string) *ScanObjectAPI {
+	return NewScanObjectAPI(api.Client, id)
+}
+
+// Schedules retrieves the schedules API.
+func (api *API) Schedules() *SchedulesAPI {
+	return NewSchedulesAPI(api.Client)
+}
+
+// Schedule retrieves the schedule API for a specific ID.
+func (api *API) Schedule(id string) *ScheduleAPI {
+	return NewScheduleAPI(api.Client, id)
+}
+
+// Heartbeat retrieves the heartbeat API.
+func (api *API) Heartbeat() *HeartbeatAPI {
+	return NewHeartbeatAPI(api.Client)
+}
+
+// HackerAlertAppliances retrieves the hacker alert appliances API.
+func (api *API) HackerAlertAppliances() *HackerAlertAppliancesAPI {
+	return NewHackerAlertAppliancesAPI(api.Client)
+}
+
+// HackerAlertAppliance retrieves the hacker alert appliance API for a specific
+// ID.
+func (api *API) HackerAlertAppliance(id string) *HackerAlertApplianceAPI {
+	return NewHackerAlertApplianceAPI(api.Client, id)
+}
+
 // APIResponse is the response wrapper for API v2.
 type APIResponse struct {
 	Data  interface{}      `json:"data"`
@@ -88,29 +125,11 @@ type APIResponse struct {
 }
 
 // APIResponseLinks contains pagination links for the API response.
-type APIResponseLinks struct {
-	First string `json:"first"`
-	Last  string `json:"last"`
-	Prev  string `json:"prev"`
-	Next  string `json:"next"`
-}
+type APIResponseLinks = api.PageLinks
 
 // APIResponseMeta contains metadata about the API response, such as pagination
 // information.
-type APIResponseMeta struct {
-	CurrentPage int                   `json:"current_page"`
-	From        int                   `json:"from"`
-	LastPage    int                   `json:"last_page"`
-	Links       []APIResponseMetaLink `json:"links"`
-	Path        string                `json:"path"`
-	PerPage     int                   `json:"per_page"`
-	To          int                   `json:"to"`
-	Total       int                   `json:"total"`
-}
+type APIResponseMeta = api.PageMeta
 
 // APIResponseMetaLink represents a link in the metadata of the API response.
-type APIResponseMetaLink struct {
-	URL    string `json:"url"`
-	Label  string `json:"label"`
-	Active bool   `json:"active"`
-}
+type APIResponseMetaLink = api.PageMetaLink
@@ -0,0 +1,32 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient starts a server answering with handler and returns a client
+// for it.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return client.New(srv.URL)
+}
+
+func TestAPI_Accessors(t *testing.T) {
+	lh := New(client.New("http://example.com"))
+
+	for want, got := range map[string]string{
+		"http://example.com/api/v2/scanobjects":   lh.ScanObjects().BuildURL(),
+		"http://example.com/api/v2/scanobjects/1": lh.ScanObject("1").BuildURL(),
+		"http://example.com/api/v2/schedules":     lh.Schedules().BuildURL(),
+		"http://example.com/api/v2/schedules/1":   lh.Schedule("1").BuildURL(),
+	} {
+		assert.Equal(t, want, got)
+	}
+}
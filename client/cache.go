@@ -0,0 +1,194 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is an HTTP response cache for GET requests made through Client.Do.
+// Responses carrying an ETag or Last-Modified header are revalidated with
+// If-None-Match / If-Modified-Since on every request and served from the
+// cache when the server answers 304 Not Modified. Responses without
+// validators are served from the cache without contacting the server until
+// TTL has passed.
+//
+// Entries are keyed by URL and the subject of the credentials used, so
+// clients authenticating as different OAuth clients never share entries. A
+// successful POST, PUT, PATCH or DELETE invalidates cached entries for the
+// same resource.
+//
+// A Cache is safe for concurrent use and may be shared between clients.
+type Cache struct {
+	// TTL is how long responses without validators are served from cache.
+	// Zero disables TTL-only caching; such responses are then not cached.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	path         string
+	body         []byte
+	etag         string
+	lastModified string
+	stored       time.Time
+}
+
+// NewCache creates a cache that serves responses without validators for ttl.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		TTL:     ttl,
+		entries: map[string]*cacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Clear removes all entries.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*cacheEntry{}
+}
+
+// Invalidate removes entries related to the resource at rawURL: every entry
+// whose path belongs to one of the collections named in rawURL. For example
+// invalidating /api/v2/scan-tasks/1/scan-results removes cached scan tasks
+// and scan results.
+func (c *Cache) Invalidate(rawURL string) {
+	collections := resourceCollections(rawURL)
+	if len(collections) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.entries {
+		for coll := range resourceCollections(e.path) {
+			if collections[coll] {
+				delete(c.entries, key)
+				break
+			}
+		}
+	}
+}
+
+func (c *Cache) lookup(key string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key]
+}
+
+// fresh reports whether e can be served without contacting the server.
+func (c *Cache) fresh(e *cacheEntry) bool {
+	if e.etag != "" || e.lastModified != "" {
+		return false
+	}
+	return c.now().Sub(e.stored) < c.TTL
+}
+
+func (c *Cache) store(key, rawURL string, body []byte, header http.Header) {
+	if strings.Contains(header.Get("Cache-Control"), "no-store") {
+		return
+	}
+	e := &cacheEntry{
+		path:         rawURL,
+		body:         body,
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+	}
+	if e.etag == "" && e.lastModified == "" && c.TTL <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.stored = c.now()
+	c.entries[key] = e
+}
+
+// versionPrefix matches the API prefix preceding resource collections.
+var versionPrefix = regexp.MustCompile(`^api/(v\d+/)?`)
+
+// resourceCollections returns the collection names in a resource URL, which
+// alternate with ids: /api/v2/probes/1/schedules yields probes and schedules.
+func resourceCollections(rawURL string) map[string]bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	path := versionPrefix.ReplaceAllString(strings.Trim(u.Path, "/"), "")
+	if path == "" {
+		return nil
+	}
+	out := map[string]bool{}
+	for i, seg := range strings.Split(path, "/") {
+		if i%2 == 0 {
+			out[seg] = true
+		}
+	}
+	return out
+}
+
+// cacheKey identifies a cached response for a subject and URL.
+func cacheKey(subject, rawURL string) string {
+	return subject + " " + rawURL
+}
+
+// cacheSubject returns a stable identifier for the credentials a request is
+// made with. Client credentials are identified by client ID, other bearer
+// tokens by their JWT subject or, failing that, a hash of the token.
+func (c *Client) cacheSubject(req *http.Request) string {
+	if grant, ok := c.OAuthClient.(*ClientCredentialsGrant); ok {
+		return "client:" + grant.ClientID
+	}
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return ""
+	}
+	if sub := jwtSubject(token); sub != "" {
+		return "sub:" + sub
+	}
+	sum := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(sum[:8])
+}
+
+// jwtSubject returns the sub claim of a JWT, falling back to aud, without
+// verifying the signature. It returns "" if token is not a JWT.
+func jwtSubject(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Sub string          `json:"sub"`
+		Aud json.RawMessage `json:"aud"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	if claims.Sub != "" {
+		return claims.Sub
+	}
+	return strings.Trim(string(claims.Aud), `"`)
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_RevalidatesWithETag(t *testing.T) {
+	var hits, notModified int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"p1"}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, WithCache(NewCache(0)))
+	for i := 0; i < 3; i++ {
+		result, err := c.Do("GET", srv.URL+"/api/v2/probes", nil)
+		require.NoError(t, err)
+		assert.Len(t, result["data"], 1)
+	}
+
+	assert.Equal(t, int32(3), hits)
+	assert.Equal(t, int32(2), notModified)
+}
+
+func TestCache_TTLWithoutValidators(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	cache := NewCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	c := New(srv.URL, WithCache(cache))
+
+	_, err := c.Do("GET", srv.URL+"/api/v2/schedules", nil)
+	require.NoError(t, err)
+	_, err = c.Do("GET", srv.URL+"/api/v2/schedules", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), hits)
+
+	now = now.Add(2 * time.Minute)
+	_, err = c.Do("GET", srv.URL+"/api/v2/schedules", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), hits)
+}
+
+func TestCache_ReadHeaderBypassesCache(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&hits, 1)
+		w.Header().Set("X-Hit", fmt.Sprint(n))
+		w.Header().Set("X-If-Match", r.Header.Get("If-Match"))
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, WithCache(NewCache(time.Hour)))
+	url := srv.URL + "/api/v2/schedules"
+	_, err := c.Do("GET", url, nil)
+	require.NoError(t, err)
+
+	var header http.Header
+	_, err = c.DoWithOptions("GET", url, nil, ReadHeader(&header), WithHeader("If-Match", `"v1"`))
+	require.NoError(t, err)
+	assert.Equal(t, "2", header.Get("X-Hit"))
+	assert.Equal(t, `"v1"`, header.Get("X-If-Match"))
+
+	_, err = c.Do("GET", url, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), hits)
+}
+
+func TestCache_MutationInvalidatesRelatedEntries(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+
+	cache := NewCache(time.Hour)
+	c := New(srv.URL, WithCache(cache))
+
+	for _, path := range []string{"/api/v2/probes?page=1", "/api/v2/probes/p1", "/api/v2/scannerplatforms"} {
+		_, err := c.Do("GET", srv.URL+path, nil)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, cache.Len())
+
+	_, err := c.Do("PUT", srv.URL+"/api/v2/probes/p1", map[string]interface{}{"name": "renamed"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, cache.Len(), "only the scanner platforms entry should remain")
+}
+
+func TestCache_KeyedBySubject(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	cache := NewCache(time.Hour)
+	a := New(srv.URL, WithCache(cache))
+	a.OAuthClient = &ClientCredentialsGrant{ClientID: "a", Token: "ta", Expiry: time.Now().Add(time.Hour)}
+	b := New(srv.URL, WithCache(cache))
+	b.OAuthClient = &ClientCredentialsGrant{ClientID: "b", Token: "tb", Expiry: time.Now().Add(time.Hour)}
+
+	_, err := a.Do("GET", srv.URL+"/api/v2/probes", nil)
+	require.NoError(t, err)
+	_, err = b.Do("GET", srv.URL+"/api/v2/probes", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestJWTSubject(t *testing.T) {
+	// {"alg":"none"}.{"sub":"42"}.sig
+	assert.Equal(t, "42", jwtSubject("eyJhbGciOiJub25lIn0.eyJzdWIiOiI0MiJ9.sig"))
+	assert.Equal(t, "", jwtSubject("opaque-token"))
+}
@@ -0,0 +1,35 @@
+package client
+
+import "net/http"
+
+// CallOption configures a single request made with Client.DoWithOptions.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	skipCoalescing bool
+	header         http.Header
+	responseHeader *http.Header
+}
+
+// SkipCoalescing makes a GET request bypass request coalescing, so it always
+// results in its own network call.
+func SkipCoalescing() CallOption {
+	return func(o *callOptions) { o.skipCoalescing = true }
+}
+
+// WithHeader sets a header on the request, such as If-Match.
+func WithHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// ReadHeader stores the response headers in dst. The request bypasses the
+// response cache and request coalescing, so the headers belong to a response
+// for this call.
+func ReadHeader(dst *http.Header) CallOption {
+	return func(o *callOptions) { o.responseHeader = dst }
+}
@@ -0,0 +1,338 @@
+// Package cassette provides a record/replay client.HttpClient for
+// deterministic tests. Exchanges with a real server are captured to a YAML or
+// JSON cassette file once and replayed offline afterwards, for example in CI.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/guardian360/go-lighthouse/client"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoMatch is returned in replay mode when no recorded interaction matches
+// a request.
+var ErrNoMatch = errors.New("cassette: no matching interaction")
+
+// Mode determines whether the recorder talks to the real server.
+type Mode int
+
+const (
+	// ModeReplay only replays recorded interactions. Requests without a
+	// matching interaction fail with ErrNoMatch.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request to the real server and records it,
+	// replacing any existing cassette when saved.
+	ModeRecord
+	// ModeReplayOrRecord replays matching interactions and records requests
+	// that have no match yet.
+	ModeReplayOrRecord
+)
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method  string      `json:"method" yaml:"method"`
+	URL     string      `json:"url" yaml:"url"`
+	Headers http.Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body    string      `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code" yaml:"status_code"`
+	Status     string      `json:"status" yaml:"status"`
+	Headers    http.Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body       string      `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// Interaction is a single recorded request/response pair.
+type Interaction struct {
+	Request  Request  `json:"request" yaml:"request"`
+	Response Response `json:"response" yaml:"response"`
+}
+
+// Cassette is the on-disk representation of a set of recorded interactions.
+type Cassette struct {
+	// Version is the cassette format version.
+	Version int `json:"version" yaml:"version"`
+	// Interactions are the recorded interactions in the order they occurred.
+	Interactions []*Interaction `json:"interactions" yaml:"interactions"`
+}
+
+// cassetteVersion is the current cassette format version.
+const cassetteVersion = 1
+
+// Load reads a cassette from the named file. Files ending in .yaml or .yml are
+// decoded as YAML, anything else as JSON.
+func Load(name string) (*Cassette, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if isYAML(name) {
+		err = yaml.Unmarshal(b, &c)
+	} else {
+		err = json.Unmarshal(b, &c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cassette: failed to decode %s: %w", name, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to the named file, using the same format rules as
+// Load.
+func (c *Cassette) Save(name string) error {
+	var (
+		b   []byte
+		err error
+	)
+	if isYAML(name) {
+		b, err = yaml.Marshal(c)
+	} else {
+		b, err = json.MarshalIndent(c, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, b, 0o644)
+}
+
+func isYAML(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Recorder is a client.HttpClient that records and replays interactions
+// using a cassette file.
+type Recorder struct {
+	path      string
+	mode      Mode
+	real      client.HttpClient
+	matchers  []Matcher
+	scrubbers []Scrubber
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+var _ client.HttpClient = (*Recorder)(nil)
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithMode sets the recording mode. The default is ModeReplay.
+func WithMode(mode Mode) Option {
+	return func(r *Recorder) { r.mode = mode }
+}
+
+// WithHTTPClient sets the client used to reach the real server when
+// recording. The default is client.NewHTTPClient().
+func WithHTTPClient(c client.HttpClient) Option {
+	return func(r *Recorder) { r.real = c }
+}
+
+// WithMatchers replaces the matchers used to find a recorded interaction for
+// a request. The default is DefaultMatchers.
+func WithMatchers(matchers ...Matcher) Option {
+	return func(r *Recorder) { r.matchers = matchers }
+}
+
+// WithScrubbers adds scrubbers that are applied to every interaction before
+// it is recorded and to every request before it is matched.
+func WithScrubbers(scrubbers ...Scrubber) Option {
+	return func(r *Recorder) { r.scrubbers = append(r.scrubbers, scrubbers...) }
+}
+
+// New creates a Recorder backed by the cassette file at path. In ModeReplay
+// the file must exist; in ModeReplayOrRecord it is loaded if it exists.
+func New(path string, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		matchers:  DefaultMatchers,
+		scrubbers: DefaultScrubbers,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.real == nil && r.mode != ModeReplay {
+		r.real = client.NewHTTPClient()
+	}
+
+	switch r.mode {
+	case ModeRecord:
+		r.cassette = &Cassette{Version: cassetteVersion}
+	case ModeReplayOrRecord:
+		c, err := Load(path)
+		if errors.Is(err, os.ErrNotExist) {
+			c, err = &Cassette{Version: cassetteVersion}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+	default:
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// Cassette returns the cassette held by the recorder.
+func (r *Recorder) Cassette() *Cassette {
+	return r.cassette
+}
+
+// Save writes the recorded interactions to the cassette file. It is a no-op
+// in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Do implements client.HttpClient.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	live, err := captureRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	probe := &Interaction{Request: live}
+	r.scrub(probe)
+
+	if r.mode != ModeRecord {
+		if i := r.match(probe.Request); i != nil {
+			return i.Response.toHTTP(req), nil
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w for %s %s", ErrNoMatch, req.Method, req.URL)
+		}
+	}
+
+	req.Body = io.NopCloser(strings.NewReader(live.Body))
+	resp, err := r.real.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := &Interaction{
+		Request: live,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Headers:    resp.Header.Clone(),
+			Body:       string(body),
+		},
+	}
+	r.scrub(recorded)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, recorded)
+	r.used = append(r.used, true)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// match returns the first unused interaction that matches req. If every
+// matching interaction has been used, the last one is replayed again so that
+// repeated identical requests keep working.
+func (r *Recorder) match(req Request) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var last *Interaction
+	for idx, i := range r.cassette.Interactions {
+		if !r.matches(req, i.Request) {
+			continue
+		}
+		if !r.used[idx] {
+			r.used[idx] = true
+			return i
+		}
+		last = i
+	}
+	return last
+}
+
+func (r *Recorder) matches(live, recorded Request) bool {
+	for _, m := range r.matchers {
+		if !m(live, recorded) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Recorder) scrub(i *Interaction) {
+	for _, s := range r.scrubbers {
+		s(i)
+	}
+}
+
+func captureRequest(req *http.Request) (Request, error) {
+	out := Request{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: req.Header.Clone(),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return out, err
+		}
+		out.Body = string(b)
+	}
+	return out, nil
+}
+
+func (r Response) toHTTP(req *http.Request) *http.Response {
+	status := r.Status
+	if status == "" {
+		status = fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode))
+	}
+	header := r.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode:    r.StatusCode,
+		Status:        status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,103 @@
+package cassette
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth/token":
+			_, _ = w.Write([]byte(`{"access_token":"live-token","token_type":"Bearer","expires_in":3600}`))
+		case "/api/v2/probes":
+			_, _ = w.Write([]byte(`{"data":[{"id":"p1","name":"probe"}],"page":"` + r.URL.Query().Get("page") + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	for _, name := range []string{"probes.yaml", "probes.json"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			srv := newServer(t)
+
+			rec, err := New(path, WithMode(ModeRecord))
+			require.NoError(t, err)
+
+			c := &client.Client{BaseURL: srv.URL, Client: rec}
+			c.WithClientCredentials(srv.URL+"/oauth/token", "id", "secret")
+			resp, err := c.Do("GET", srv.URL+"/api/v2/probes?page=1", nil)
+			require.NoError(t, err)
+			assert.Equal(t, "1", resp["page"])
+			require.NoError(t, rec.Save())
+			srv.Close()
+
+			saved, err := Load(path)
+			require.NoError(t, err)
+			require.Len(t, saved.Interactions, 2)
+			token := saved.Interactions[0]
+			assert.Contains(t, token.Request.Body, "client_secret=%5BSCRUBBED%5D")
+			assert.NotContains(t, token.Response.Body, "live-token")
+			assert.Equal(t, scrubbedValue, saved.Interactions[1].Request.Headers.Get("Authorization"))
+
+			replay, err := New(path)
+			require.NoError(t, err)
+			c = &client.Client{BaseURL: "http://offline.invalid", Client: replay}
+			c.WithClientCredentials("http://offline.invalid/oauth/token", "id", "secret")
+			resp, err = c.Do("GET", "http://offline.invalid/api/v2/probes?page=1", nil)
+			require.NoError(t, err)
+			assert.Equal(t, "1", resp["page"])
+		})
+	}
+}
+
+func TestRecorder_ReplayMiss(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, (&Cassette{Version: cassetteVersion}).Save(path))
+
+	rec, err := New(path)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", "http://example.com/api/v2/probes", nil)
+	_, err = rec.Do(req)
+	assert.True(t, errors.Is(err, ErrNoMatch))
+}
+
+func TestRecorder_ReplayOrRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mixed.yml")
+	srv := newServer(t)
+	defer srv.Close()
+
+	rec, err := New(path, WithMode(ModeReplayOrRecord), WithMatchers(MatchMethod, MatchPath, MatchQuery, MatchBody))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", srv.URL+"/api/v2/probes?page=2", nil)
+		resp, err := rec.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+	assert.Len(t, rec.Cassette().Interactions, 1)
+}
+
+func TestMatchBody_ComparesJSONSemantically(t *testing.T) {
+	assert.True(t, MatchBody(Request{Body: `{"a":1,"b":2}`}, Request{Body: `{ "b": 2, "a": 1 }`}))
+	assert.False(t, MatchBody(Request{Body: `{"a":1}`}, Request{Body: `{"a":2}`}))
+}
+
+func TestMatchQuery_IgnoresOrder(t *testing.T) {
+	assert.True(t, MatchQuery(Request{URL: "http://a/x?a=1&b=2"}, Request{URL: "http://b/x?b=2&a=1"}))
+	assert.False(t, MatchQuery(Request{URL: "http://a/x?a=1"}, Request{URL: "http://a/x?a=2"}))
+}
@@ -0,0 +1,146 @@
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Matcher reports whether a live request matches a recorded one.
+type Matcher func(live, recorded Request) bool
+
+// DefaultMatchers match on method, path and query.
+var DefaultMatchers = []Matcher{MatchMethod, MatchPath, MatchQuery}
+
+// MatchMethod matches requests with the same HTTP method.
+func MatchMethod(live, recorded Request) bool {
+	return strings.EqualFold(live.Method, recorded.Method)
+}
+
+// MatchPath matches requests with the same URL path. The scheme and host are
+// ignored so that cassettes recorded against one server can be replayed
+// against another.
+func MatchPath(live, recorded Request) bool {
+	l, err1 := url.Parse(live.URL)
+	r, err2 := url.Parse(recorded.URL)
+	if err1 != nil || err2 != nil {
+		return live.URL == recorded.URL
+	}
+	return l.Path == r.Path
+}
+
+// MatchQuery matches requests with the same query parameters, regardless of
+// their order.
+func MatchQuery(live, recorded Request) bool {
+	l, err1 := url.Parse(live.URL)
+	r, err2 := url.Parse(recorded.URL)
+	if err1 != nil || err2 != nil {
+		return live.URL == recorded.URL
+	}
+	return reflect.DeepEqual(l.Query(), r.Query())
+}
+
+// MatchBody matches requests with the same body. JSON bodies are compared
+// semantically, so key order and whitespace do not matter.
+func MatchBody(live, recorded Request) bool {
+	if live.Body == recorded.Body {
+		return true
+	}
+	var l, r interface{}
+	if json.Unmarshal([]byte(live.Body), &l) != nil || json.Unmarshal([]byte(recorded.Body), &r) != nil {
+		return false
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+// Scrubber removes or rewrites sensitive data in an interaction before it is
+// written to a cassette.
+type Scrubber func(i *Interaction)
+
+// scrubbedValue replaces scrubbed values.
+const scrubbedValue = "[SCRUBBED]"
+
+// DefaultScrubbers remove the Authorization header, the OAuth client secret
+// and issued access tokens.
+var DefaultScrubbers = []Scrubber{
+	ScrubHeaders("Authorization", "Cookie", "Set-Cookie"),
+	ScrubFormFields("client_secret"),
+	ScrubJSONFields("access_token", "refresh_token"),
+}
+
+// ScrubHeaders replaces the values of the named request and response headers.
+func ScrubHeaders(names ...string) Scrubber {
+	return func(i *Interaction) {
+		for _, name := range names {
+			scrubHeader(i.Request.Headers, name)
+			scrubHeader(i.Response.Headers, name)
+		}
+	}
+}
+
+func scrubHeader(h http.Header, name string) {
+	if h.Get(name) != "" {
+		h.Set(name, scrubbedValue)
+	}
+}
+
+// ScrubFormFields replaces the values of the named fields in form-encoded
+// request bodies.
+func ScrubFormFields(names ...string) Scrubber {
+	return func(i *Interaction) {
+		if !strings.HasPrefix(i.Request.Headers.Get("Content-Type"), "application/x-www-form-urlencoded") {
+			return
+		}
+		values, err := url.ParseQuery(i.Request.Body)
+		if err != nil {
+			return
+		}
+		for _, name := range names {
+			if values.Has(name) {
+				values.Set(name, scrubbedValue)
+			}
+		}
+		i.Request.Body = values.Encode()
+	}
+}
+
+// ScrubJSONFields replaces the values of the named top-level fields in JSON
+// request and response bodies.
+func ScrubJSONFields(names ...string) Scrubber {
+	return func(i *Interaction) {
+		i.Request.Body = scrubJSON(i.Request.Body, names)
+		i.Response.Body = scrubJSON(i.Response.Body, names)
+	}
+}
+
+func scrubJSON(body string, names []string) string {
+	var doc map[string]interface{}
+	if json.Unmarshal([]byte(body), &doc) != nil {
+		return body
+	}
+	changed := false
+	for _, name := range names {
+		if _, ok := doc[name]; ok {
+			doc[name] = scrubbedValue
+			changed = true
+		}
+	}
+	if !changed {
+		return body
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
+
+// ScrubBody rewrites request and response bodies with fn.
+func ScrubBody(fn func(string) string) Scrubber {
+	return func(i *Interaction) {
+		i.Request.Body = fn(i.Request.Body)
+		i.Response.Body = fn(i.Response.Body)
+	}
+}
@@ -29,8 +29,21 @@ type Client struct {
 	OAuthClient OAuthClient
 	// Logger is the optional logger for the client.
 	Logger Logger
-}
+	// Cache is the optional response cache for GET requests.
+	Cache *Cache
+	// Coalesce turns on request coalescing: identical GET requests (same URL
+	// and credentials) made concurrently share a single network call and its
+	// decoded result. The callers then share the same result map, so it is
+	// off by default.
+	Coalesce bool
+	// Strict controls how responses that do not match the API types are
+	// handled. See StrictMode.
+	Strict StrictMode
+	// Drift accumulates schema drift when Strict is not StrictOff.
+	Drift *DriftReport
 
+	inflight inflightGroup
+}
 
 // NewHTTPClient creates an *http.Client with retry logic using
 // go-retryablehttp. The returned client transparently retries on 5xx (except
@@ -59,6 +72,9 @@ func NewHTTPClient(opts ...Option) *http.Client {
 	rc.HTTPClient.Transport = &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: o.insecure},
 	}
+	if o.har != nil {
+		rc.HTTPClient.Transport = o.har.Transport(rc.HTTPClient.Transport)
+	}
 
 	return rc.StandardClient()
 }
@@ -66,11 +82,18 @@ func NewHTTPClient(opts ...Option) *http.Client {
 // New creates a new Lighthouse API client.
 func New(baseURL string, opts ...Option) *Client {
 	o := applyOptions(opts)
-	return &Client{
-		BaseURL: baseURL,
-		Client:  NewHTTPClient(opts...),
-		Logger:  o.logger,
+	c := &Client{
+		BaseURL:  baseURL,
+		Client:   NewHTTPClient(opts...),
+		Logger:   o.logger,
+		Cache:    o.cache,
+		Coalesce: o.coalesce,
+		Strict:   o.strict,
 	}
+	if o.strict != StrictOff {
+		c.Drift = NewDriftReport()
+	}
+	return c
 }
 
 // WithClientCredentials configures OAuth client credentials grant
@@ -103,7 +126,21 @@ func (c *Client) SetHeaders(req *http.Request) error {
 }
 
 // Do performs an HTTP request with the given method, URL, and parameters.
+//
+// If Coalesce is set, concurrent identical GET requests are coalesced; the
+// callers then share the same result map, which must be treated as
+// read-only.
 func (c *Client) Do(method, url string, params map[string]interface{}) (map[string]interface{}, error) {
+	return c.DoWithOptions(method, url, params)
+}
+
+// DoWithOptions is like Do but accepts per-call options.
+func (c *Client) DoWithOptions(method, url string, params map[string]interface{}, opts ...CallOption) (map[string]interface{}, error) {
+	var o callOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var buf io.ReadWriter
 	if params != nil {
 		buf = new(bytes.Buffer)
@@ -120,12 +157,49 @@ func (c *Client) Do(method, url string, params map[string]interface{}) (map[stri
 	if err := c.SetHeaders(req); err != nil {
 		return nil, err
 	}
+	for key := range o.header {
+		req.Header.Set(key, o.header.Get(key))
+	}
+
+	if method == http.MethodGet && c.Coalesce && !o.skipCoalescing && o.responseHeader == nil {
+		return c.inflight.do(c.cacheKey(req), func() (map[string]interface{}, error) {
+			return c.send(req, url, o.responseHeader)
+		})
+	}
+	return c.send(req, url, o.responseHeader)
+}
+
+// send performs a prepared request, consulting the response cache if one is
+// configured, and decodes the JSON response. If header is not nil, the cache
+// is not consulted and the response headers are stored in it.
+func (c *Client) send(req *http.Request, url string, header *http.Header) (map[string]interface{}, error) {
+	method := req.Method
+
+	var key string
+	var cached *cacheEntry
+	if c.Cache != nil && method == http.MethodGet && header == nil {
+		key = c.cacheKey(req)
+		if cached = c.Cache.lookup(key); cached != nil {
+			if c.Cache.fresh(cached) {
+				return decodeResult(method, url, cached.body)
+			}
+			if cached.etag != "" {
+				req.Header.Set("If-None-Match", cached.etag)
+			}
+			if cached.lastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.lastModified)
+			}
+		}
+	}
 
 	resp, err := c.Client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
+	if header != nil {
+		*header = resp.Header.Clone()
+	}
 
 	// Read the response body first
 	body, err := io.ReadAll(resp.Body)
@@ -133,6 +207,10 @@ func (c *Client) Do(method, url string, params map[string]interface{}) (map[stri
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return decodeResult(method, url, cached.body)
+	}
+
 	// Check status code before attempting JSON decode
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return nil, &APIError{
@@ -144,12 +222,35 @@ func (c *Client) Do(method, url string, params map[string]interface{}) (map[stri
 		}
 	}
 
-	// Try to decode JSON response
+	result, err := decodeResult(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Cache != nil {
+		if method == http.MethodGet {
+			if header == nil {
+				c.Cache.store(key, url, body, resp.Header)
+			}
+		} else {
+			c.Cache.Invalidate(url)
+		}
+	}
+
+	return result, nil
+}
+
+// cacheKey returns the response cache key for a prepared request.
+func (c *Client) cacheKey(req *http.Request) string {
+	return cacheKey(c.cacheSubject(req), req.URL.String())
+}
+
+// decodeResult decodes a JSON response body.
+func decodeResult(method, url string, body []byte) (map[string]interface{}, error) {
 	var result map[string]interface{}
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode JSON response from %s %s: %w (body: %s)",
 			method, url, err, truncateBody(string(body)))
 	}
-
 	return result, nil
 }
@@ -0,0 +1,57 @@
+package client
+
+import "sync"
+
+// inflightGroup coalesces concurrent calls with the same key so that only
+// one of them runs and the others wait for and share its result. The zero
+// value is ready to use.
+type inflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+type inflightCall struct {
+	done   chan struct{}
+	result map[string]interface{}
+	err    error
+	// waiters is the number of callers sharing this call besides the one
+	// that runs it.
+	waiters int
+}
+
+// do runs fn once per key at a time. Callers arriving while a call for the
+// same key is in flight receive that call's result instead of running fn.
+func (g *inflightGroup) do(key string, fn func() (map[string]interface{}, error)) (map[string]interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*inflightCall{}
+	}
+	if call, ok := g.calls[key]; ok {
+		call.waiters++
+		g.mu.Unlock()
+		<-call.done
+		return call.result, call.err
+	}
+	call := &inflightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.result, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+
+	return call.result, call.err
+}
+
+// waiters returns the number of callers waiting on the call for key.
+func (g *inflightGroup) waiters(key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if call, ok := g.calls[key]; ok {
+		return call.waiters
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingServer answers every request with the same JSON body once release
+// is closed.
+func blockingServer(t *testing.T, hits *int32, release chan struct{}) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		<-release
+		_, _ = w.Write([]byte(`{"data":{"id":"p1"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestClient_Do_CoalescesConcurrentGETs(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	srv := blockingServer(t, &hits, release)
+
+	c := New(srv.URL, WithCoalescing())
+	url := srv.URL + "/api/v2/probes/p1"
+	key := cacheKey("", url)
+
+	const callers = 10
+	results := make([]map[string]interface{}, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := c.Do("GET", url, nil)
+			assert.NoError(t, err)
+			results[i] = result
+		}(i)
+	}
+
+	waitFor(t, func() bool { return c.inflight.waiters(key) == callers-1 })
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), hits)
+	for _, r := range results {
+		require.NotNil(t, r)
+		assert.Equal(t, "p1", r["data"].(map[string]interface{})["id"])
+	}
+}
+
+func TestClient_DoWithOptions_SkipCoalescing(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	srv := blockingServer(t, &hits, release)
+
+	c := New(srv.URL, WithCoalescing())
+	url := srv.URL + "/api/v2/probes/p1"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.DoWithOptions("GET", url, nil, SkipCoalescing())
+			assert.NoError(t, err)
+		}()
+	}
+
+	waitFor(t, func() bool { return atomic.LoadInt32(&hits) == 3 })
+	close(release)
+	wg.Wait()
+}
+
+func TestClient_Do_DoesNotCoalesceMutations(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	srv := blockingServer(t, &hits, release)
+
+	c := New(srv.URL, WithCoalescing())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Do("POST", srv.URL+"/api/v2/probes", map[string]interface{}{"name": "probe"})
+			assert.NoError(t, err)
+		}()
+	}
+
+	waitFor(t, func() bool { return atomic.LoadInt32(&hits) == 2 })
+	close(release)
+	wg.Wait()
+}
+
+func TestClient_Do_DoesNotCoalesceByDefault(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	srv := blockingServer(t, &hits, release)
+
+	c := New(srv.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Do("GET", srv.URL+"/api/v2/probes/p1", nil)
+			assert.NoError(t, err)
+		}()
+	}
+
+	waitFor(t, func() bool { return atomic.LoadInt32(&hits) == 2 })
+	close(release)
+	wg.Wait()
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// StrictMode controls how the API packages react when a response does not
+// match the Go types it is decoded into.
+type StrictMode int
+
+const (
+	// StrictOff ignores unknown and missing fields. This is the default.
+	StrictOff StrictMode = iota
+	// StrictLog records drift in the client's DriftReport and logs a warning
+	// through the client's Logger.
+	StrictLog
+	// StrictError records drift like StrictLog and fails the request with a
+	// *DriftError.
+	StrictError
+)
+
+// String returns the name of the mode.
+func (m StrictMode) String() string {
+	switch m {
+	case StrictOff:
+		return "off"
+	case StrictLog:
+		return "log"
+	case StrictError:
+		return "error"
+	}
+	return fmt.Sprintf("StrictMode(%d)", int(m))
+}
+
+// TypeDrift describes how responses decoded into one Go type differed from
+// that type. Unknown holds fields present in responses but not in the type,
+// Missing holds fields of the type absent from responses, and Invalid holds
+// fields whose value could not be decoded and was replaced by the zero
+// value; all map the JSON field name to the number of times it was seen.
+type TypeDrift struct {
+	Type    string
+	Unknown map[string]int
+	Missing map[string]int
+	Invalid map[string]int
+}
+
+// DriftReport accumulates schema drift per Go type across requests. It is
+// safe for concurrent use.
+type DriftReport struct {
+	mu    sync.Mutex
+	types map[string]*TypeDrift
+}
+
+// NewDriftReport creates an empty DriftReport.
+func NewDriftReport() *DriftReport {
+	return &DriftReport{types: map[string]*TypeDrift{}}
+}
+
+// Record adds the unknown, missing and invalid fields seen while decoding
+// typ.
+func (r *DriftReport) Record(typ string, unknown, missing, invalid []string) {
+	if len(unknown) == 0 && len(missing) == 0 && len(invalid) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.types[typ]
+	if !ok {
+		d = &TypeDrift{Type: typ, Unknown: map[string]int{}, Missing: map[string]int{}, Invalid: map[string]int{}}
+		r.types[typ] = d
+	}
+	for _, f := range unknown {
+		d.Unknown[f]++
+	}
+	for _, f := range missing {
+		d.Missing[f]++
+	}
+	for _, f := range invalid {
+		d.Invalid[f]++
+	}
+}
+
+// Types returns a copy of the drift recorded so far, sorted by type name.
+func (r *DriftReport) Types() []TypeDrift {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]TypeDrift, 0, len(r.types))
+	for _, d := range r.types {
+		c := TypeDrift{Type: d.Type, Unknown: map[string]int{}, Missing: map[string]int{}, Invalid: map[string]int{}}
+		for k, v := range d.Unknown {
+			c.Unknown[k] = v
+		}
+		for k, v := range d.Missing {
+			c.Missing[k] = v
+		}
+		for k, v := range d.Invalid {
+			c.Invalid[k] = v
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Type < out[j].Type })
+	return out
+}
+
+// Empty reports whether no drift has been recorded.
+func (r *DriftReport) Empty() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.types) == 0
+}
+
+// Reset discards all recorded drift.
+func (r *DriftReport) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types = map[string]*TypeDrift{}
+}
+
+// String formats the report with one line per type, for example:
+//
+//	v2.Probe: unknown [firmware(3)] missing [memory(3)]
+func (r *DriftReport) String() string {
+	var b strings.Builder
+	for _, d := range r.Types() {
+		b.WriteString(d.Type + ":")
+		if len(d.Unknown) > 0 {
+			b.WriteString(" unknown [" + countedFields(d.Unknown) + "]")
+		}
+		if len(d.Missing) > 0 {
+			b.WriteString(" missing [" + countedFields(d.Missing) + "]")
+		}
+		if len(d.Invalid) > 0 {
+			b.WriteString(" invalid [" + countedFields(d.Invalid) + "]")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func countedFields(m map[string]int) string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		names[i] = fmt.Sprintf("%s(%d)", name, m[name])
+	}
+	return strings.Join(names, " ")
+}
+
+// DriftError is returned in StrictError mode when a response does not match
+// the type it is decoded into.
+type DriftError struct {
+	// Type is the Go type the response was decoded into.
+	Type string
+	// Unknown lists response fields the type does not declare.
+	Unknown []string
+	// Missing lists fields of the type the response did not contain.
+	Missing []string
+	// Invalid lists fields whose value could not be decoded.
+	Invalid []string
+}
+
+func (e *DriftError) Error() string {
+	var parts []string
+	if len(e.Unknown) > 0 {
+		parts = append(parts, "unknown fields "+strings.Join(e.Unknown, ", "))
+	}
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing fields "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Invalid) > 0 {
+		parts = append(parts, "invalid fields "+strings.Join(e.Invalid, ", "))
+	}
+	msg := "client: response does not match " + e.Type
+	if len(parts) > 0 {
+		msg += ": " + strings.Join(parts, "; ")
+	}
+	return msg
+}
+
+// ReportDrift handles unknown, missing and invalid fields found while
+// decoding a response for url into typ, according to the client's
+// StrictMode. It returns a *DriftError in StrictError mode and nil
+// otherwise.
+func (c *Client) ReportDrift(url, typ string, unknown, missing, invalid []string) error {
+	if c.Strict == StrictOff || (len(unknown) == 0 && len(missing) == 0 && len(invalid) == 0) {
+		return nil
+	}
+	if c.Drift != nil {
+		c.Drift.Record(typ, unknown, missing, invalid)
+	}
+	if c.Logger != nil {
+		c.Logger.Warn("response does not match type",
+			"url", url,
+			"type", typ,
+			"unknown", unknown,
+			"missing", missing,
+			"invalid", invalid,
+		)
+	}
+	if c.Strict == StrictError {
+		return &DriftError{Type: typ, Unknown: unknown, Missing: missing, Invalid: invalid}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportDrift_Modes(t *testing.T) {
+	c := New("http://example.com")
+	assert.NoError(t, c.ReportDrift("/api/v2/probes", "v2.Probe", []string{"firmware"}, nil, nil))
+	assert.Nil(t, c.Drift)
+
+	logger := &mockLogger{}
+	c = New("http://example.com", WithStrictMode(StrictLog), WithLogger(logger))
+	assert.NoError(t, c.ReportDrift("/api/v2/probes", "v2.Probe", []string{"firmware"}, nil, nil))
+	assert.NoError(t, c.ReportDrift("/api/v2/probes", "v2.Probe", nil, nil, nil))
+	assert.Equal(t, []string{"response does not match type"}, logger.messages)
+
+	c = New("http://example.com", WithStrictMode(StrictError))
+	err := c.ReportDrift("/api/v2/probes", "v2.Probe", []string{"firmware"}, []string{"memory"}, []string{"cpu_cores"})
+	var driftErr *DriftError
+	require.True(t, errors.As(err, &driftErr))
+	assert.Equal(t, "client: response does not match v2.Probe: unknown fields firmware; missing fields memory; invalid fields cpu_cores", err.Error())
+	assert.False(t, c.Drift.Empty())
+}
+
+func TestDriftReport_String(t *testing.T) {
+	r := NewDriftReport()
+	r.Record("v2.Probe", []string{"firmware"}, []string{"memory"}, nil)
+	r.Record("v2.Probe", []string{"firmware"}, nil, []string{"cpu_cores"})
+	r.Record("v1.Company", nil, []string{"email"}, nil)
+
+	assert.Equal(t, "v1.Company: missing [email(1)]\nv2.Probe: unknown [firmware(2)] missing [memory(1)] invalid [cpu_cores(1)]\n", r.String())
+
+	r.Reset()
+	assert.True(t, r.Empty())
+}
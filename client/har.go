@@ -0,0 +1,508 @@
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redactedValue replaces secrets in recorded traffic.
+const redactedValue = "[REDACTED]"
+
+// redactedHeaders are request and response headers whose values are never
+// written to a recording.
+var redactedHeaders = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"set-cookie":    true,
+}
+
+// redactedFields are form and JSON body fields and query parameters whose
+// values are never written to a recording, in lower case. Names are matched
+// case-insensitively.
+var redactedFields = map[string]bool{
+	"client_secret": true,
+	"access_token":  true,
+	"refresh_token": true,
+	"password":      true,
+	"token":         true,
+	"api_key":       true,
+	"signature":     true,
+}
+
+// HARRecorder records HTTP exchanges as HTTP Archive (HAR 1.2) entries. Every
+// attempt made by the HTTP client is recorded as a separate entry, so retries
+// and token requests show up exactly as they were sent. Secrets such as the
+// Authorization header and OAuth client secrets are redacted.
+//
+// A HARRecorder is safe for concurrent use.
+type HARRecorder struct {
+	mu      sync.Mutex
+	entries []HAREntry
+}
+
+// NewHARRecorder creates a new, empty HARRecorder.
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{}
+}
+
+// Entries returns a copy of the entries recorded so far.
+func (r *HARRecorder) Entries() []HAREntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]HAREntry(nil), r.entries...)
+}
+
+// HAR returns the recorded entries as a HAR document.
+func (r *HARRecorder) HAR() *HAR {
+	return &HAR{
+		Log: HARLog{
+			Version: "1.2",
+			Creator: HARCreator{Name: "go-lighthouse", Version: "1.0"},
+			Entries: r.Entries(),
+		},
+	}
+}
+
+// WriteTo writes the recorded entries as a HAR document to w.
+func (r *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.MarshalIndent(r.HAR(), "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// WriteFile writes the recorded entries as a HAR document to the named file,
+// creating or truncating it.
+func (r *HARRecorder) WriteFile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := r.WriteTo(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Reset discards all recorded entries.
+func (r *HARRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+func (r *HARRecorder) add(e HAREntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, e)
+}
+
+// Transport wraps next so that every round trip through it is recorded. If
+// next is nil, http.DefaultTransport is used.
+func (r *HARRecorder) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &harTransport{next: next, recorder: r}
+}
+
+// harTransport is an http.RoundTripper that records exchanges to a
+// HARRecorder.
+type harTransport struct {
+	next     http.RoundTripper
+	recorder *HARRecorder
+}
+
+// harTimes are the connection timings of a single exchange.
+type harTimes struct {
+	start, dnsStart, dnsDone, connectStart, connectDone time.Time
+	tlsStart, tlsDone, gotConn, wroteRequest, firstByte time.Time
+}
+
+// harTimer collects connection timings from httptrace. The trace callbacks
+// may run on other goroutines than the round trip, such as the dialer's, so
+// the timings are guarded by mu.
+type harTimer struct {
+	mu    sync.Mutex
+	times harTimes
+}
+
+func newHARTimer() *harTimer {
+	return &harTimer{times: harTimes{start: time.Now()}}
+}
+
+// mark sets the timing at p, which points into t.times, to the current time.
+func (t *harTimer) mark(p *time.Time) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*p = now
+}
+
+// snapshot returns a copy of the timings collected so far.
+func (t *harTimer) snapshot() harTimes {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.times
+}
+
+func (t *harTimer) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.mark(&t.times.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.mark(&t.times.dnsDone) },
+		ConnectStart:         func(string, string) { t.mark(&t.times.connectStart) },
+		ConnectDone:          func(string, string, error) { t.mark(&t.times.connectDone) },
+		TLSHandshakeStart:    func() { t.mark(&t.times.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.mark(&t.times.tlsDone) },
+		GotConn:              func(httptrace.GotConnInfo) { t.mark(&t.times.gotConn) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.mark(&t.times.wroteRequest) },
+		GotFirstResponseByte: func() { t.mark(&t.times.firstByte) },
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *harTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	timer := newHARTimer()
+	traced := req.WithContext(httptrace.WithClientTrace(req.Context(), timer.trace()))
+
+	resp, err := t.next.RoundTrip(traced)
+	if err != nil {
+		t.recorder.add(newHAREntry(req, reqBody, nil, nil, timer.snapshot(), time.Now()))
+		return nil, err
+	}
+
+	respBody, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	end := time.Now()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	t.recorder.add(newHAREntry(req, reqBody, resp, respBody, timer.snapshot(), end))
+
+	if readErr != nil {
+		return nil, readErr
+	}
+	return resp, nil
+}
+
+// HAR is the root of an HTTP Archive document.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the log object of an HTTP Archive.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator identifies the application that created the archive.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a single recorded HTTP exchange.
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+// HARRequest is the request half of a recorded exchange.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse is the response half of a recorded exchange.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARNameValue is a name/value pair used for headers, cookies and query
+// parameters.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData describes a request body.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent describes a response body.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARTimings contains the timing breakdown of an exchange in milliseconds.
+// Phases that did not occur (for example DNS on a reused connection) are -1.
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+func newHAREntry(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, t harTimes, end time.Time) HAREntry {
+	entry := HAREntry{
+		StartedDateTime: t.start,
+		Time:            millis(t.start, end),
+		Request: HARRequest{
+			Method:      req.Method,
+			URL:         redactURL(req.URL),
+			HTTPVersion: req.Proto,
+			Cookies:     []HARNameValue{},
+			Headers:     harHeaders(req.Header),
+			QueryString: harQuery(req.URL.Query()),
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: HARResponse{
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: harTimings(t, end),
+	}
+	if entry.Request.HTTPVersion == "" {
+		entry.Request.HTTPVersion = "HTTP/1.1"
+	}
+	if reqBody != nil {
+		mime := req.Header.Get("Content-Type")
+		entry.Request.PostData = &HARPostData{
+			MimeType: mime,
+			Text:     redactBody(mime, reqBody),
+		}
+	}
+
+	if resp == nil {
+		entry.Comment = "request failed before a response was received"
+		return entry
+	}
+
+	mime := resp.Header.Get("Content-Type")
+	entry.Response.Status = resp.StatusCode
+	entry.Response.StatusText = strings.TrimSpace(strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode)))
+	if entry.Response.StatusText == "" {
+		entry.Response.StatusText = http.StatusText(resp.StatusCode)
+	}
+	entry.Response.HTTPVersion = resp.Proto
+	entry.Response.Headers = harHeaders(resp.Header)
+	entry.Response.RedirectURL = resp.Header.Get("Location")
+	entry.Response.BodySize = len(respBody)
+	entry.Response.Content = HARContent{
+		Size:     len(respBody),
+		MimeType: mime,
+		Text:     redactBody(mime, respBody),
+	}
+	return entry
+}
+
+func harHeaders(h http.Header) []HARNameValue {
+	out := []HARNameValue{}
+	for name, values := range h {
+		for _, v := range values {
+			if redactedHeaders[strings.ToLower(name)] {
+				v = redactedValue
+			}
+			out = append(out, HARNameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+func harQuery(q url.Values) []HARNameValue {
+	out := []HARNameValue{}
+	for name, values := range q {
+		for _, v := range values {
+			if redactedFields[strings.ToLower(name)] {
+				v = redactedValue
+			}
+			out = append(out, HARNameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+// redactURL returns u as a string with the values of secret query parameters
+// redacted.
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	redacted := false
+	for name := range q {
+		if redactedFields[strings.ToLower(name)] {
+			q.Set(name, redactedValue)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	c := *u
+	c.RawQuery = q.Encode()
+	return c.String()
+}
+
+func harTimings(t harTimes, end time.Time) HARTimings {
+	// HAR counts the TLS handshake as part of connect.
+	connectDone := t.connectDone
+	if !t.tlsDone.IsZero() {
+		connectDone = t.tlsDone
+	}
+	timings := HARTimings{
+		Blocked: -1,
+		DNS:     span(t.dnsStart, t.dnsDone),
+		Connect: span(t.connectStart, connectDone),
+		SSL:     span(t.tlsStart, t.tlsDone),
+		Send:    span(t.gotConn, t.wroteRequest),
+		Wait:    span(t.wroteRequest, t.firstByte),
+		Receive: span(t.firstByte, end),
+	}
+	if !t.gotConn.IsZero() {
+		timings.Blocked = millis(t.start, t.gotConn)
+		// Connection setup happens before GotConn; HAR expects blocked to
+		// exclude the dns and connect phases.
+		for _, d := range []float64{timings.DNS, timings.Connect} {
+			if d > 0 {
+				timings.Blocked -= d
+			}
+		}
+		if timings.Blocked < 0 {
+			timings.Blocked = 0
+		}
+	}
+	if timings.Send < 0 {
+		timings.Send = 0
+	}
+	if timings.Wait < 0 {
+		timings.Wait = 0
+	}
+	if timings.Receive < 0 {
+		timings.Receive = 0
+	}
+	return timings
+}
+
+// span returns the duration between two trace points in milliseconds, or -1
+// if either did not occur.
+func span(from, to time.Time) float64 {
+	if from.IsZero() || to.IsZero() {
+		return -1
+	}
+	return millis(from, to)
+}
+
+func millis(from, to time.Time) float64 {
+	return float64(to.Sub(from)) / float64(time.Millisecond)
+}
+
+// redactBody removes secret values from form-encoded and JSON bodies, matching
+// field names case-insensitively. In JSON bodies, fields of nested objects
+// and arrays are redacted too. Bodies of other types are returned unchanged.
+func redactBody(mime string, body []byte) string {
+	switch {
+	case strings.HasPrefix(mime, "application/x-www-form-urlencoded"):
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return string(body)
+		}
+		for name := range values {
+			if redactedFields[strings.ToLower(name)] {
+				values.Set(name, redactedValue)
+			}
+		}
+		return values.Encode()
+	case strings.Contains(mime, "json"):
+		var doc interface{}
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return string(body)
+		}
+		if !redactJSON(doc) {
+			return string(body)
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return string(body)
+		}
+		return string(b)
+	}
+	return string(body)
+}
+
+// redactJSON redacts secret fields in the decoded JSON value v and the
+// objects and arrays nested in it. It reports whether anything was redacted.
+func redactJSON(v interface{}) bool {
+	redacted := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for name, value := range v {
+			if redactedFields[strings.ToLower(name)] {
+				v[name] = redactedValue
+				redacted = true
+			} else if redactJSON(value) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if redactJSON(item) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHARRecorder_RecordsRetriesAndTokenRequests(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"secret-token","token_type":"Bearer","expires_in":3600}`))
+		default:
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":"ok"}`))
+		}
+	}))
+	defer srv.Close()
+
+	rec := NewHARRecorder()
+	c := New(srv.URL, WithHARRecorder(rec)).
+		WithClientCredentials(srv.URL+"/oauth/token", "client-id", "client-secret")
+
+	_, err := c.Do("GET", srv.URL+"/api/v2/health?page=2", nil)
+	require.NoError(t, err)
+
+	entries := rec.Entries()
+	require.Len(t, entries, 3)
+
+	token := entries[0]
+	assert.Equal(t, "POST", token.Request.Method)
+	require.NotNil(t, token.Request.PostData)
+	assert.NotContains(t, token.Request.PostData.Text, "client-secret")
+	assert.Contains(t, token.Request.PostData.Text, "client_id=client-id")
+	assert.NotContains(t, token.Response.Content.Text, "secret-token")
+
+	assert.Equal(t, http.StatusServiceUnavailable, entries[1].Response.Status)
+	assert.Equal(t, http.StatusOK, entries[2].Response.Status)
+	assert.Equal(t, `{"data":"ok"}`, entries[2].Response.Content.Text)
+	assert.Contains(t, entries[2].Request.QueryString, HARNameValue{Name: "page", Value: "2"})
+	assert.Contains(t, entries[2].Request.Headers, HARNameValue{Name: "Authorization", Value: redactedValue})
+	assert.GreaterOrEqual(t, entries[2].Timings.Wait, float64(0))
+}
+
+func TestHARRecorder_WriteTo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	rec := NewHARRecorder()
+	c := &http.Client{Transport: rec.Transport(nil)}
+	resp, err := c.Post(srv.URL, "application/json", bytes.NewBufferString(`{"name":"probe"}`))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, `{}`, string(body))
+
+	var buf bytes.Buffer
+	_, err = rec.WriteTo(&buf)
+	require.NoError(t, err)
+
+	var har HAR
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &har))
+	assert.Equal(t, "1.2", har.Log.Version)
+	require.Len(t, har.Log.Entries, 1)
+	assert.Equal(t, `{"name":"probe"}`, har.Log.Entries[0].Request.PostData.Text)
+}
+
+func TestHARRecorder_RecordsTransportErrors(t *testing.T) {
+	rec := NewHARRecorder()
+	c := &http.Client{Transport: rec.Transport(nil)}
+
+	_, err := c.Get("http://127.0.0.1:1/unreachable")
+	require.Error(t, err)
+
+	entries := rec.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, 0, entries[0].Response.Status)
+	assert.NotEmpty(t, entries[0].Comment)
+}
+
+func TestHARRecorder_RedactsQueryParameters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	rec := NewHARRecorder()
+	c := &http.Client{Transport: rec.Transport(nil)}
+	resp, err := c.Get(srv.URL + "/export?access_token=secret&page=2")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	entry := rec.Entries()[0]
+	assert.NotContains(t, entry.Request.URL, "secret")
+	assert.Contains(t, entry.Request.URL, "page=2")
+	assert.Contains(t, entry.Request.QueryString, HARNameValue{Name: "access_token", Value: redactedValue})
+}
+
+func TestRedactBody_MixedCaseAndNestedFields(t *testing.T) {
+	form := redactBody("application/x-www-form-urlencoded", []byte("Client_Secret=s1&PASSWORD=s2&grant_type=client_credentials"))
+	assert.NotContains(t, form, "s1")
+	assert.NotContains(t, form, "s2")
+	assert.Contains(t, form, "grant_type=client_credentials")
+
+	body := redactBody("application/json", []byte(`{"Password":"s1","user":{"Api_Key":"s2","name":"ops"},"tokens":[{"Token":"s3"}]}`))
+	assert.NotContains(t, body, "s1")
+	assert.NotContains(t, body, "s2")
+	assert.NotContains(t, body, "s3")
+	assert.Contains(t, body, `"name":"ops"`)
+
+	assert.Equal(t, `{"name":"ops"}`, redactBody("application/json", []byte(`{"name":"ops"}`)))
+}
+
+func TestHARRecorder_ConcurrentRequests(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	rec := NewHARRecorder()
+	c := &http.Client{Transport: rec.Transport(srv.Client().Transport)}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Get(srv.URL)
+			if assert.NoError(t, err) {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, rec.Entries(), 8)
+}
@@ -6,6 +6,11 @@ type Option func(*options)
 type options struct {
 	insecure bool
 	logger   Logger
+	har      *HARRecorder
+	cache    *Cache
+
+	coalesce bool
+	strict   StrictMode
 }
 
 // WithInsecure disables TLS certificate verification.
@@ -18,6 +23,31 @@ func WithLogger(logger Logger) Option {
 	return func(o *options) { o.logger = logger }
 }
 
+// WithHARRecorder records every HTTP exchange, including retries and OAuth
+// token requests, to the given HARRecorder.
+func WithHARRecorder(recorder *HARRecorder) Option {
+	return func(o *options) { o.har = recorder }
+}
+
+// WithCache enables response caching for GET requests made by the client.
+func WithCache(cache *Cache) Option {
+	return func(o *options) { o.cache = cache }
+}
+
+// WithCoalescing makes concurrent identical GET requests share a single
+// network call. The callers receive the same result map, which must be
+// treated as read-only.
+func WithCoalescing() Option {
+	return func(o *options) { o.coalesce = true }
+}
+
+// WithStrictMode makes the client check responses against the API types
+// they are decoded into, logging or failing on unknown and missing fields
+// depending on mode. Drift is accumulated in Client.Drift.
+func WithStrictMode(mode StrictMode) Option {
+	return func(o *options) { o.strict = mode }
+}
+
 func applyOptions(opts []Option) options {
 	var o options
 	for _, opt := range opts {
@@ -0,0 +1,496 @@
+// Command apigen generates Lighthouse API bindings from the OpenAPI document
+// in openapi/. For every path carrying an x-go extension it emits the model,
+// the collection or item API type built on api.Collection and api.Item, its
+// constructor and accessor, and an api.Field variable per scalar property of
+// the model into the v2 package, and
+// a fixture per model into the lighthousetest package.
+//
+// It is run through go generate:
+//
+//	go generate ./api/v2
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path of the OpenAPI document")
+	apiOut := flag.String("api", "", "output file for the API package")
+	fixturesOut := flag.String("fixtures", "", "output file for the lighthousetest package")
+	flag.Parse()
+
+	if err := run(*specPath, *apiOut, *fixturesOut); err != nil {
+		fmt.Fprintln(os.Stderr, "apigen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, apiOut, fixturesOut string) error {
+	doc, err := loadDocument(specPath)
+	if err != nil {
+		return err
+	}
+	apiSrc, fixturesSrc, err := generate(doc, specPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(apiOut, apiSrc, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(fixturesOut, fixturesSrc, 0o644)
+}
+
+// resource is a path of the document, as an API type to generate.
+type resource struct {
+	goExtension
+	path   string
+	item   bool
+	params []string // Go names of the path parameters, the item id last
+	patch  bool     // the item is updated with PATCH rather than PUT
+	sort   []string
+}
+
+// generate returns the formatted sources of the API and fixtures files.
+func generate(doc *document, specPath string) ([]byte, []byte, error) {
+	var resources []resource
+	var models []string
+	seen := map[string]bool{}
+	for _, path := range doc.Paths.Keys {
+		r, err := newResource(doc, path, doc.Paths.Values[path])
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if r == nil {
+			continue
+		}
+		resources = append(resources, *r)
+		if !seen[r.Resource] {
+			seen[r.Resource] = true
+			models = append(models, r.Resource)
+		}
+	}
+
+	header := "// Code generated by apigen from " + filepath.Base(specPath) + ". DO NOT EDIT.\n\n"
+
+	var api bytes.Buffer
+	api.WriteString(header + "package v2\n\nimport (\n\t\"encoding/json\"\n\n" +
+		"\t\"github.com/guardian360/go-lighthouse/api\"\n" +
+		"\t\"github.com/guardian360/go-lighthouse/client\"\n)\n")
+	for _, name := range models {
+		s, ok := doc.Components.Schemas[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown schema %s", name)
+		}
+		if err := writeModel(&api, name, s); err != nil {
+			return nil, nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+		for _, r := range resources {
+			if r.Resource == name && !r.item {
+				if err := writeFields(&api, name, r, s); err != nil {
+					return nil, nil, fmt.Errorf("%s: %w", r.path, err)
+				}
+			}
+		}
+		for _, r := range resources {
+			if r.Resource == name {
+				writeResource(&api, r)
+			}
+		}
+	}
+	apiSrc, err := format.Source(api.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("formatting API source: %w", err)
+	}
+
+	var fixtures bytes.Buffer
+	fixtures.WriteString(header + "package lighthousetest\n")
+	for _, name := range models {
+		noun := name
+		for _, r := range resources {
+			if r.Resource == name && r.item {
+				noun = r.Noun
+			}
+		}
+		writeFixture(&fixtures, name, noun, doc.Components.Schemas[name])
+	}
+	fixturesSrc, err := format.Source(fixtures.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("formatting fixtures source: %w", err)
+	}
+	return apiSrc, fixturesSrc, nil
+}
+
+// newResource describes the API type for a path, or returns nil if the path
+// has no x-go extension.
+func newResource(doc *document, path string, item *pathItem) (*resource, error) {
+	if item.Go.Type == "" {
+		return nil, nil
+	}
+	r := &resource{
+		goExtension: item.Go,
+		path:        path,
+		item:        strings.HasSuffix(path, "}"),
+		patch:       item.Patch != nil && item.Put == nil,
+	}
+
+	for _, p := range item.Parameters {
+		p, err := doc.resolveParameter(p)
+		if err != nil {
+			return nil, err
+		}
+		if p.In != "path" {
+			continue
+		}
+		name := p.GoName
+		if name == "" {
+			name = lowerFirst(goName(p.Name))
+		}
+		r.params = append(r.params, name)
+	}
+	if r.item {
+		if len(r.params) == 0 {
+			return nil, fmt.Errorf("item path without parameters")
+		}
+		r.params[len(r.params)-1] = "id"
+	}
+	parents := len(r.params)
+	if r.item {
+		parents--
+	}
+	if (parents > 0) != (r.Parent != "") || parents > 1 {
+		return nil, fmt.Errorf("nested paths need exactly one parent parameter and x-go.parent")
+	}
+
+	if item.Get != nil {
+		for _, p := range item.Get.Parameters {
+			p, err := doc.resolveParameter(p)
+			if err != nil {
+				return nil, err
+			}
+			if p.Name == "sort" && p.Schema != nil {
+				r.sort = p.Schema.SortKeys
+			}
+		}
+	}
+	return r, nil
+}
+
+// urlExpr returns the Go expression building the resource URL from c and
+// the path parameters.
+func (r resource) urlExpr() string {
+	var parts []string
+	literal := ""
+	param := 0
+	for _, seg := range strings.Split(strings.TrimPrefix(r.path, "/"), "/") {
+		if strings.HasPrefix(seg, "{") {
+			parts = append(parts, strconv.Quote(literal+"/"), r.params[param])
+			literal = ""
+			param++
+			continue
+		}
+		literal += "/" + seg
+	}
+	if literal != "" {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return "c.BaseURL + " + strings.Join(parts, " + ")
+}
+
+func writeModel(b *bytes.Buffer, name string, s *schema) error {
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	b.WriteString("\n" + comment("", s.Description))
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, prop := range s.Properties.Keys {
+		p := s.Properties.Values[prop]
+		typ, err := goType(p)
+		if err != nil {
+			return fmt.Errorf("property %s: %w", prop, err)
+		}
+		field := p.GoName
+		if field == "" {
+			field = goName(prop)
+		}
+		tag := prop
+		if !required[prop] {
+			if typ == "api.Time" || (p.GoType != "" && !strings.HasPrefix(p.GoType, "[]")) {
+				tag += ",omitzero"
+			} else {
+				tag += ",omitempty"
+			}
+		}
+		b.WriteString(comment("\t", p.Description))
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", field, typ, tag)
+	}
+	b.WriteString("\t// Extra holds response fields not declared above.\n")
+	b.WriteString("\tExtra map[string]json.RawMessage `json:\"-\"`\n}\n")
+
+	alias := lowerFirst(name)
+	recv := alias[:1]
+	noun := words(name)
+	fmt.Fprintf(b, `
+// UnmarshalJSON decodes %[1]s, keeping unknown fields in Extra.
+func (%[2]s *%[3]s) UnmarshalJSON(data []byte) error {
+	type %[4]s %[3]s
+	return api.UnmarshalWithExtra(data, (*%[4]s)(%[2]s), &%[2]s.Extra)
+}
+
+// MarshalJSON encodes %[1]s, including the fields in Extra.
+func (%[2]s %[3]s) MarshalJSON() ([]byte, error) {
+	type %[4]s %[3]s
+	return api.MarshalWithExtra(%[4]s(%[2]s), %[2]s.Extra)
+}
+`, article(noun)+" "+noun, recv, name, alias)
+	return nil
+}
+
+// writeFields writes an api.Field variable for every scalar property of the
+// model, naming the ones the collection r sorts on. Sort keys must be scalar
+// properties, so that every key has a field.
+func writeFields(b *bytes.Buffer, name string, r resource, s *schema) error {
+	var vars bytes.Buffer
+	fields := map[string]string{}
+	for _, prop := range s.Properties.Keys {
+		p := s.Properties.Values[prop]
+		if p.Ref != "" || p.Type == "array" || p.Type == "object" {
+			continue
+		}
+		field := p.GoName
+		if field == "" {
+			field = goName(prop)
+		}
+		fields[prop] = name + field
+		fmt.Fprintf(&vars, "\t%s%s = api.NewField[%s](%q)\n", name, field, name, prop)
+	}
+	var sortable []string
+	for _, key := range r.sort {
+		field, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("sort key %s is not a scalar property of %s", key, name)
+		}
+		sortable = append(sortable, field)
+	}
+
+	doc := fmt.Sprintf("Fields of %s, for filtering, sorting and selecting with %s.Where, SortBy and Fields.", name, r.Type)
+	if len(sortable) > 0 {
+		doc += fmt.Sprintf(" %s sorts on %s.", r.Type, enumerate(sortable))
+	}
+	b.WriteString("\n" + comment("", doc) + "var (\n")
+	b.Write(vars.Bytes())
+	b.WriteString(")\n")
+	return nil
+}
+
+// enumerate joins words as a, b and c.
+func enumerate(words []string) string {
+	if len(words) == 1 {
+		return words[0]
+	}
+	return strings.Join(words[:len(words)-1], ", ") + " and " + words[len(words)-1]
+}
+
+func writeResource(b *bytes.Buffer, r resource) {
+	var params []string
+	for _, p := range r.params {
+		params = append(params, p+" string")
+	}
+	ctorParams := strings.Join(append([]string{"c *client.Client"}, params...), ", ")
+
+	if !r.item {
+		fmt.Fprintf(b, "\n// %s is the API for the %s resource.\n", r.Type, r.Noun)
+		fmt.Fprintf(b, "type %s struct {\n\tapi.Collection[%s]\n}\n", r.Type, r.Resource)
+		fmt.Fprintf(b, "\n// %sResponse is the response structure for the %s API.\n", r.Type, r.Noun)
+		fmt.Fprintf(b, "type %sResponse = api.List[%s]\n", r.Type, r.Resource)
+		fmt.Fprintf(b, "\n// New%s creates a new %s instance.\n", r.Type, r.Type)
+		fmt.Fprintf(b, "func New%s(%s) *%s {\n", r.Type, ctorParams, r.Type)
+		fmt.Fprintf(b, "\treturn &%s{Collection: *api.NewCollection[%s](c, %s)}\n}\n", r.Type, r.Resource, r.urlExpr())
+	} else {
+		fmt.Fprintf(b, "\n// %s is the API for a single %s.\n", r.Type, r.Noun)
+		fmt.Fprintf(b, "type %s struct {\n\tapi.Item[%s]\n}\n", r.Type, r.Resource)
+		fmt.Fprintf(b, "\n// %sResponse is the response structure for a single %s.\n", r.Type, r.Noun)
+		fmt.Fprintf(b, "type %sResponse = api.Response[%s]\n", r.Type, r.Resource)
+		fmt.Fprintf(b, "\n// New%s creates a new %s instance.\n", r.Type, r.Type)
+		fmt.Fprintf(b, "func New%s(%s) *%s {\n", r.Type, ctorParams, r.Type)
+		if r.patch {
+			fmt.Fprintf(b, "\ts := &%s{Item: *api.NewItem[%s](c, %s, id)}\n", r.Type, r.Resource, r.urlExpr())
+			b.WriteString("\ts.UpdateMethod = \"PATCH\"\n\treturn s\n}\n")
+		} else {
+			fmt.Fprintf(b, "\treturn &%s{Item: *api.NewItem[%s](c, %s, id)}\n}\n", r.Type, r.Resource, r.urlExpr())
+		}
+	}
+
+	// Accessor on *API for top-level paths, on the parent item otherwise.
+	recv, recvType, args := "api", "API", []string{"api.Client"}
+	if r.Parent != "" {
+		recv, recvType = strings.ToLower(r.Parent[:1]), r.Parent
+		args = []string{recv + ".Client", recv + ".ID"}
+	}
+	sig, what := "()", ""
+	if r.item {
+		sig, what = "(id string)", " for a specific ID"
+		args = append(args, "id")
+	}
+	if r.Parent != "" && !r.item {
+		fmt.Fprintf(b, "\n// %s retrieves the %s for a %s.\n", r.Accessor, r.Noun, words(strings.TrimSuffix(r.Parent, "API")))
+	} else {
+		fmt.Fprintf(b, "\n// %s retrieves the %s API%s.\n", r.Accessor, r.Noun, what)
+	}
+	fmt.Fprintf(b, "func (%s *%s) %s%s *%s {\n", recv, recvType, r.Accessor, sig, r.Type)
+	fmt.Fprintf(b, "\treturn New%s(%s)\n}\n", r.Type, strings.Join(args, ", "))
+}
+
+func writeFixture(b *bytes.Buffer, name, noun string, s *schema) {
+	b.WriteString("\n" + comment("", fmt.Sprintf("%sFixture returns %s %s record built from the "+
+		"examples in the API description, ready to be inserted into the store.", name, article(noun), noun)))
+	fmt.Fprintf(b, "func %sFixture() Record {\n\treturn Record{\n", name)
+	for _, prop := range s.Properties.Keys {
+		p := s.Properties.Values[prop]
+		if p.ReadOnly || p.Example == nil {
+			continue
+		}
+		fmt.Fprintf(b, "\t\t%q: %s,\n", prop, goLiteral(p.Example))
+	}
+	b.WriteString("\t}\n}\n")
+}
+
+// goType returns the Go type for a property schema.
+func goType(p *schema) (string, error) {
+	if p.GoType != "" {
+		return p.GoType, nil
+	}
+	if p.Ref != "" {
+		return "*" + schemaName(p.Ref), nil
+	}
+	switch p.Type {
+	case "string":
+		if p.Format == "date-time" {
+			return "api.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "object":
+		return "map[string]interface{}", nil
+	case "array":
+		if p.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		if p.Items.Ref != "" {
+			return "[]" + schemaName(p.Items.Ref), nil
+		}
+		elem, err := goType(p.Items)
+		return "[]" + elem, err
+	}
+	return "", fmt.Errorf("unsupported type %q", p.Type)
+}
+
+// goLiteral formats a YAML example value as a Go literal.
+func goLiteral(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			items = append(items, goLiteral(item))
+		}
+		return "[]interface{}{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprint(v)
+}
+
+// initialisms are name segments written in upper case in Go names.
+var initialisms = map[string]string{
+	"api": "API", "cpu": "CPU", "dns": "DNS", "http": "HTTP", "id": "ID",
+	"ip": "IP", "ipv4": "IPv4", "ssl": "SSL", "url": "URL", "uuid": "UUID",
+}
+
+// goName converts a snake_case or kebab-case name to an exported Go name.
+func goName(s string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' }) {
+		if upper, ok := initialisms[part]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// words splits a Go name into lower case words: ScanObjectExclusion becomes
+// "scan object exclusion".
+func words(name string) string {
+	var out []string
+	start := 0
+	runes := []rune(name)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			out = append(out, string(runes[start:i]))
+			start = i
+		}
+	}
+	out = append(out, string(runes[start:]))
+	for i, w := range out {
+		if _, ok := initialisms[strings.ToLower(w)]; !ok {
+			out[i] = strings.ToLower(w)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+func article(noun string) string {
+	if strings.ContainsRune("aeiou", rune(noun[0])) {
+		return "an"
+	}
+	return "a"
+}
+
+// comment formats text as a Go comment wrapped at 80 columns, counting a
+// tab as four.
+func comment(indent, text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return ""
+	}
+	width := 80 - 3 - 4*strings.Count(indent, "\t")
+	var lines []string
+	line := ""
+	for _, w := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(w) > width {
+			lines = append(lines, line)
+			line = w
+			continue
+		}
+		if line != "" {
+			line += " "
+		}
+		line += w
+	}
+	lines = append(lines, line)
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(indent + "// " + l + "\n")
+	}
+	return b.String()
+}
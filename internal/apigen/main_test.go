@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_MatchesCommittedCode(t *testing.T) {
+	doc, err := loadDocument("../../openapi/lighthouse-v2.yaml")
+	require.NoError(t, err)
+	apiSrc, fixturesSrc, err := generate(doc, "../../openapi/lighthouse-v2.yaml")
+	require.NoError(t, err)
+
+	for file, want := range map[string][]byte{
+		"../../api/v2/resources_gen.go":        apiSrc,
+		"../../lighthousetest/fixtures_gen.go": fixturesSrc,
+	} {
+		got, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "%s is out of date, run go generate ./api/v2", file)
+	}
+}
+
+func TestResource_URLExpr(t *testing.T) {
+	r := resource{path: "/api/v2/scanobjects/{scanobject}/exclusions/{exclusion}", params: []string{"scanObjectID", "id"}}
+	assert.Equal(t, `c.BaseURL + "/api/v2/scanobjects/" + scanObjectID + "/exclusions/" + id`, r.urlExpr())
+
+	r = resource{path: "/api/v2/companies"}
+	assert.Equal(t, `c.BaseURL + "/api/v2/companies"`, r.urlExpr())
+}
+
+func TestWriteFields_RejectsUnknownSortKeys(t *testing.T) {
+	s := &schema{Properties: ordered[*schema]{
+		Keys:   []string{"name", "company"},
+		Values: map[string]*schema{"name": {Type: "string"}, "company": {Ref: "#/components/schemas/Company"}},
+	}}
+	var b bytes.Buffer
+	require.NoError(t, writeFields(&b, "Widget", resource{goExtension: goExtension{Type: "WidgetsAPI"}, sort: []string{"name"}}, s))
+	assert.Contains(t, b.String(), "WidgetsAPI sorts on WidgetName.")
+
+	err := writeFields(&b, "Widget", resource{goExtension: goExtension{Type: "WidgetsAPI"}, sort: []string{"company"}}, s)
+	assert.EqualError(t, err, "sort key company is not a scalar property of Widget")
+}
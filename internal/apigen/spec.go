@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// document is the subset of an OpenAPI 3 document the generator reads.
+type document struct {
+	Paths      ordered[*pathItem] `yaml:"paths"`
+	Components struct {
+		Schemas    map[string]*schema    `yaml:"schemas"`
+		Parameters map[string]*parameter `yaml:"parameters"`
+	} `yaml:"components"`
+}
+
+// pathItem is an OpenAPI path item with the x-go extension.
+type pathItem struct {
+	Go         goExtension  `yaml:"x-go"`
+	Parameters []*parameter `yaml:"parameters"`
+	Get        *operation   `yaml:"get"`
+	Post       *operation   `yaml:"post"`
+	Put        *operation   `yaml:"put"`
+	Patch      *operation   `yaml:"patch"`
+	Delete     *operation   `yaml:"delete"`
+}
+
+// goExtension names the Go types generated for a path.
+type goExtension struct {
+	Resource string `yaml:"resource"`
+	Type     string `yaml:"type"`
+	Accessor string `yaml:"accessor"`
+	Parent   string `yaml:"parent"`
+	Noun     string `yaml:"noun"`
+}
+
+type operation struct {
+	Parameters []*parameter `yaml:"parameters"`
+}
+
+type parameter struct {
+	Ref    string  `yaml:"$ref"`
+	Name   string  `yaml:"name"`
+	In     string  `yaml:"in"`
+	GoName string  `yaml:"x-go-name"`
+	Schema *schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref         string           `yaml:"$ref"`
+	Type        string           `yaml:"type"`
+	Format      string           `yaml:"format"`
+	Description string           `yaml:"description"`
+	Required    []string         `yaml:"required"`
+	Properties  ordered[*schema] `yaml:"properties"`
+	Items       *schema          `yaml:"items"`
+	ReadOnly    bool             `yaml:"readOnly"`
+	Nullable    bool             `yaml:"nullable"`
+	Example     interface{}      `yaml:"example"`
+	GoName      string           `yaml:"x-go-name"`
+	GoType      string           `yaml:"x-go-type"`
+	SortKeys    []string         `yaml:"x-sort-keys"`
+}
+
+// ordered is a YAML mapping that remembers the order of its keys, so that
+// generated code follows the order of the document.
+type ordered[T any] struct {
+	Keys   []string
+	Values map[string]T
+}
+
+func (o *ordered[T]) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", n.Line)
+	}
+	o.Values = map[string]T{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		var v T
+		if err := n.Content[i+1].Decode(&v); err != nil {
+			return err
+		}
+		key := n.Content[i].Value
+		o.Keys = append(o.Keys, key)
+		o.Values[key] = v
+	}
+	return nil
+}
+
+// loadDocument reads and parses the OpenAPI document at path.
+func loadDocument(path string) (*document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &doc, nil
+}
+
+// resolveParameter follows a $ref to a component parameter.
+func (d *document) resolveParameter(p *parameter) (*parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name := strings.TrimPrefix(p.Ref, "#/components/parameters/")
+	resolved, ok := d.Components.Parameters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown parameter %s", p.Ref)
+	}
+	return resolved, nil
+}
+
+// schemaName returns the component name a schema $ref points to.
+func schemaName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
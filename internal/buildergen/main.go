@@ -0,0 +1,190 @@
+// Command buildergen generates the query builder methods of the resource
+// API types in a package. The builders of api.Collection and api.Item, such
+// as Page and With, return the generic type, so calling one on a ProbeAPI
+// would lose the methods ProbeAPI adds. For every struct type embedding
+// api.Collection[T] or api.Item[T], buildergen emits wrappers of those
+// builders returning the struct type itself, leaving out methods the type
+// already declares. Embedded Collection[T] and Item[T] types of the package
+// itself, such as the v1 aliases, are recognised as well.
+//
+// It is run through go generate:
+//
+//	go generate ./api/v1 ./api/v2
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package")
+	out := flag.String("out", "builders_gen.go", "output file, relative to dir")
+	flag.Parse()
+
+	src, err := generate(*dir, *out)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(*dir, *out), src, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "buildergen:", err)
+		os.Exit(1)
+	}
+}
+
+// builder is a method of api.Collection or api.Item that returns its
+// receiver.
+type builder struct {
+	name   string
+	doc    string
+	params string // parameters, with T standing for the resource type
+	args   string
+}
+
+// collectionBuilders are the builders of api.Collection.
+var collectionBuilders = []builder{
+	{"Page", "Page sets the page number for pagination.", "page int", "page"},
+	{"PerPage", "PerPage sets the number of items per page for pagination.", "perPage int", "perPage"},
+	{"Scopes", "Scopes sets the scopes to filter by.", "scopes ...string", "scopes..."},
+	{"With", "With sets the relationships to include in the response.", "relationships ...string", "relationships..."},
+	{"Sort", "Sort sets the sorting key and order.", "sort, order string", "sort, order"},
+	{"Where", "Where filters the collection on the given conditions.", "conditions ...api.Condition[T]", "conditions..."},
+	{"SortBy", "SortBy sorts the collection on one or more fields, the first taking\n// precedence. It replaces any sort set with Sort.", "sorts ...api.Sort[T]", "sorts..."},
+	{"Fields", "Fields limits the fields included for each resource.", "fields ...api.Field[T]", "fields..."},
+}
+
+// itemBuilders are the builders of api.Item.
+var itemBuilders = []builder{
+	{"With", "With sets the relationships to include in the response.", "relationships ...string", "relationships..."},
+	{"Fields", "Fields limits the fields included in the resource.", "fields ...api.Field[T]", "fields..."},
+}
+
+// resourceType is a struct type embedding api.Collection or api.Item.
+type resourceType struct {
+	name     string
+	embedded string // "Collection" or "Item"
+	model    string
+}
+
+// generate returns the formatted source of the builders file for the
+// package in dir, ignoring the existing output file out.
+func generate(dir, out string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == out {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s: no Go files", dir)
+	}
+
+	var types []resourceType
+	declared := map[string]bool{} // "Type.Method"
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						if t, ok := embedsResource(ts); ok {
+							types = append(types, t)
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if recv := receiverName(decl); recv != "" {
+					declared[recv+"."+decl.Name.Name] = true
+				}
+			}
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].name < types[j].name })
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by buildergen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\nimport \"github.com/guardian360/go-lighthouse/api\"\n", files[0].Name.Name)
+	for _, t := range types {
+		builders := collectionBuilders
+		if t.embedded == "Item" {
+			builders = itemBuilders
+		}
+		for _, m := range builders {
+			if declared[t.name+"."+m.name] {
+				continue
+			}
+			params := strings.ReplaceAll(m.params, "[T]", "["+t.model+"]")
+			fmt.Fprintf(&b, "\n// %s\nfunc (r *%s) %s(%s) *%s {\n\tr.%s.%s(%s)\n\treturn r\n}\n",
+				m.doc, t.name, m.name, params, t.name, t.embedded, m.name, m.args)
+		}
+	}
+	return format.Source(b.Bytes())
+}
+
+// embedsResource reports whether ts is a struct type embedding
+// api.Collection[T] or api.Item[T], and returns it if so.
+func embedsResource(ts *ast.TypeSpec) (resourceType, bool) {
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok || ts.TypeParams != nil {
+		return resourceType{}, false
+	}
+	for _, f := range st.Fields.List {
+		if len(f.Names) != 0 {
+			continue
+		}
+		idx, ok := f.Type.(*ast.IndexExpr)
+		if !ok {
+			continue
+		}
+		var name string
+		switch x := idx.X.(type) {
+		case *ast.SelectorExpr:
+			if pkg, ok := x.X.(*ast.Ident); ok && pkg.Name == "api" {
+				name = x.Sel.Name
+			}
+		case *ast.Ident:
+			name = x.Name
+		}
+		model, ok := idx.Index.(*ast.Ident)
+		if (name == "Collection" || name == "Item") && ok {
+			return resourceType{name: ts.Name.Name, embedded: name, model: model.Name}, true
+		}
+	}
+	return resourceType{}, false
+}
+
+// receiverName returns the name of the type of the receiver of fn, or "" if
+// fn is not a method.
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
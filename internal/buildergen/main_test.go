@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/guardian360/go-lighthouse/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_MatchesCommittedCode(t *testing.T) {
+	for _, pkg := range []string{"v1", "v2"} {
+		want, err := generate("../../api/"+pkg, "builders_gen.go")
+		require.NoError(t, err)
+		got, err := os.ReadFile("../../api/" + pkg + "/builders_gen.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "api/%s/builders_gen.go is out of date, run go generate ./api/%s", pkg, pkg)
+	}
+}
+
+func TestBuilders_CoverGenericBuilders(t *testing.T) {
+	for typ, builders := range map[reflect.Type][]builder{
+		reflect.TypeOf(&api.Collection[struct{}]{}): collectionBuilders,
+		reflect.TypeOf(&api.Item[struct{}]{}):       itemBuilders,
+	} {
+		var want, got []string
+		for i := 0; i < typ.NumMethod(); i++ {
+			m := typ.Method(i).Type
+			if m.NumOut() == 1 && m.Out(0) == typ {
+				want = append(want, typ.Method(i).Name)
+			}
+		}
+		for _, b := range builders {
+			got = append(got, b.name)
+		}
+		assert.ElementsMatch(t, want, got, "builders of %s", typ)
+	}
+}
@@ -0,0 +1,293 @@
+package lighthousetest
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"time"
+)
+
+// FaultKind is the kind of failure a Fault injects.
+type FaultKind int
+
+const (
+	// FaultStatus responds with Status, an optional Retry-After header and
+	// Body instead of handling the request.
+	FaultStatus FaultKind = iota
+	// FaultTruncate handles the request but sends only the first half of the
+	// response body, so the client receives incomplete JSON.
+	FaultTruncate
+	// FaultInvalidJSON responds with a 200 and a non-JSON body.
+	FaultInvalidJSON
+	// FaultSlowBody handles the request but streams the response body in
+	// small chunks with Delay between them.
+	FaultSlowBody
+	// FaultReset closes the connection without sending a response.
+	FaultReset
+)
+
+// String returns a readable name for the fault kind.
+func (k FaultKind) String() string {
+	switch k {
+	case FaultStatus:
+		return "status"
+	case FaultTruncate:
+		return "truncate"
+	case FaultInvalidJSON:
+		return "invalid-json"
+	case FaultSlowBody:
+		return "slow-body"
+	case FaultReset:
+		return "reset"
+	}
+	return "unknown"
+}
+
+// Fault describes a failure injected into matching requests.
+type Fault struct {
+	// Kind is the kind of failure.
+	Kind FaultKind
+	// Path is a path.Match pattern matched against the request path, such as
+	// "/api/v2/probes/*". An empty pattern matches every path.
+	Path string
+	// Method restricts the fault to a single HTTP method if set.
+	Method string
+	// Probability is the chance in [0, 1] that a matching request fails. Zero
+	// means always.
+	Probability float64
+	// Times limits how often the fault fires. Zero means unlimited.
+	Times int
+	// Status is the status code for FaultStatus.
+	Status int
+	// RetryAfter is the Retry-After header value for FaultStatus, if set.
+	RetryAfter string
+	// Body is the response body for FaultStatus and FaultInvalidJSON.
+	Body string
+	// Delay is the pause between chunks for FaultSlowBody.
+	Delay time.Duration
+
+	fired int
+}
+
+// RateLimited returns a fault that responds 429 Too Many Requests with the
+// given Retry-After value, times times.
+func RateLimited(pattern, retryAfter string, times int) Fault {
+	return Fault{
+		Kind:       FaultStatus,
+		Path:       pattern,
+		Times:      times,
+		Status:     http.StatusTooManyRequests,
+		RetryAfter: retryAfter,
+		Body:       `{"message":"Too Many Attempts."}`,
+	}
+}
+
+// ServerErrors returns a fault that responds with a burst of times 5xx
+// responses of the given status.
+func ServerErrors(pattern string, status, times int) Fault {
+	return Fault{
+		Kind:   FaultStatus,
+		Path:   pattern,
+		Times:  times,
+		Status: status,
+		Body:   `<!DOCTYPE html><html><body><h1>` + http.StatusText(status) + `</h1></body></html>`,
+	}
+}
+
+// TruncatedJSON returns a fault that cuts response bodies in half.
+func TruncatedJSON(pattern string, times int) Fault {
+	return Fault{Kind: FaultTruncate, Path: pattern, Times: times}
+}
+
+// InvalidJSON returns a fault that responds 200 with a non-JSON body.
+func InvalidJSON(pattern string, times int) Fault {
+	return Fault{Kind: FaultInvalidJSON, Path: pattern, Times: times, Body: "<html>maintenance</html>"}
+}
+
+// SlowBody returns a fault that streams response bodies with delay between
+// chunks.
+func SlowBody(pattern string, delay time.Duration) Fault {
+	return Fault{Kind: FaultSlowBody, Path: pattern, Delay: delay}
+}
+
+// ConnectionReset returns a fault that drops the connection without a
+// response.
+func ConnectionReset(pattern string, times int) Fault {
+	return Fault{Kind: FaultReset, Path: pattern, Times: times}
+}
+
+// TokenOutage returns a fault that makes the OAuth token endpoint respond 503
+// Service Unavailable times times.
+func TokenOutage(times int) Fault {
+	f := ServerErrors("/oauth/token", http.StatusServiceUnavailable, times)
+	f.RetryAfter = "0"
+	return f
+}
+
+// RequestLog describes a request received by the server and how it was
+// answered.
+type RequestLog struct {
+	// Method is the HTTP method.
+	Method string
+	// Path is the request path.
+	Path string
+	// Query is the raw query string.
+	Query string
+	// Status is the response status code, or 0 if the connection was reset.
+	Status int
+	// Fault is the kind of fault injected, if any.
+	Fault *FaultKind
+}
+
+// String formats the entry as "METHOD /path STATUS", which makes request
+// sequences easy to compare in tests.
+func (l RequestLog) String() string {
+	s := l.Method + " " + l.Path + " " + strconv.Itoa(l.Status)
+	if l.Fault != nil {
+		s += " (" + l.Fault.String() + ")"
+	}
+	return s
+}
+
+// InjectFault adds a fault. Faults are evaluated in the order they were
+// added; the first one that matches and fires is applied.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fault := f
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// SetSeed seeds the random source used for fault probabilities.
+func (s *Server) SetSeed(seed uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pcg.Seed(seed, seed)
+}
+
+// Requests returns the requests the server received, in order.
+func (s *Server) Requests() []RequestLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RequestLog(nil), s.requests...)
+}
+
+// ResetRequests clears the request log.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// nextFault returns the fault to apply to r, if any.
+func (s *Server) nextFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range s.faults {
+		if f.Times > 0 && f.fired >= f.Times {
+			continue
+		}
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.Path != "" {
+			if ok, _ := path.Match(f.Path, r.URL.Path); !ok {
+				continue
+			}
+		}
+		if f.Probability > 0 && s.rand.Float64() >= f.Probability {
+			continue
+		}
+		f.fired++
+		return f
+	}
+	return nil
+}
+
+func (s *Server) logRequest(r *http.Request, status int, fault *Fault) {
+	entry := RequestLog{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Status: status}
+	if fault != nil {
+		kind := fault.Kind
+		entry.Fault = &kind
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, entry)
+}
+
+// serveFault applies f to the request and returns the status sent.
+func (s *Server) serveFault(w http.ResponseWriter, r *http.Request, f *Fault) int {
+	switch f.Kind {
+	case FaultStatus:
+		if f.RetryAfter != "" {
+			w.Header().Set("Retry-After", f.RetryAfter)
+		}
+		w.WriteHeader(f.Status)
+		_, _ = w.Write([]byte(f.Body))
+		return f.Status
+	case FaultInvalidJSON:
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(f.Body))
+		return http.StatusOK
+	case FaultReset:
+		resetConnection(w)
+		return 0
+	}
+
+	rec := httptest.NewRecorder()
+	s.serve(rec, r)
+	body := rec.Body.Bytes()
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+
+	if f.Kind == FaultTruncate {
+		body = body[:len(body)/2]
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(body)
+		return rec.Code
+	}
+
+	w.WriteHeader(rec.Code)
+	flusher, _ := w.(http.Flusher)
+	const chunk = 16
+	for len(body) > 0 {
+		n := min(chunk, len(body))
+		_, _ = w.Write(body[:n])
+		if flusher != nil {
+			flusher.Flush()
+		}
+		body = body[n:]
+		if len(body) > 0 {
+			time.Sleep(f.Delay)
+		}
+	}
+	return rec.Code
+}
+
+// resetConnection closes the underlying connection with SO_LINGER set to 0,
+// which makes the client observe a connection reset.
+func resetConnection(w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		panic(http.ErrAbortHandler)
+	}
+	conn, _, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		_ = tcp.SetLinger(0)
+	}
+	_ = conn.Close()
+}
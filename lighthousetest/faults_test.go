@@ -0,0 +1,140 @@
+package lighthousetest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	v2 "github.com/guardian360/go-lighthouse/api/v2"
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/guardian360/go-lighthouse/lighthousetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requestLines(srv *lighthousetest.Server) []string {
+	var out []string
+	for _, r := range srv.Requests() {
+		out = append(out, r.String())
+	}
+	return out
+}
+
+func TestFaults_RateLimitIsRetried(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	srv.InjectFault(lighthousetest.RateLimited("/api/v2/probes", "0", 2))
+
+	_, err := v2.New(srv.Client()).Probes().Get()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"POST /oauth/token 200",
+		"GET /api/v2/probes 429 (status)",
+		"GET /api/v2/probes 429 (status)",
+		"GET /api/v2/probes 200",
+	}, requestLines(srv))
+}
+
+func TestFaults_TokenOutage(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	srv.InjectFault(lighthousetest.TokenOutage(1))
+
+	_, err := v2.New(srv.Client()).Probes().Get()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"POST /oauth/token 503 (status)",
+		"POST /oauth/token 200",
+		"GET /api/v2/probes 200",
+	}, requestLines(srv))
+}
+
+func TestFaults_PersistentServerErrorSurfacesAsError(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	f := lighthousetest.ServerErrors("/api/v2/probes/*", 503, 0)
+	f.RetryAfter = "0"
+	srv.InjectFault(f)
+
+	_, err := v2.New(srv.Client()).Probe("p1").Get()
+	require.Error(t, err)
+	assert.Len(t, srv.Requests(), 5, "token request plus initial attempt and three retries")
+}
+
+func TestFaults_InvalidAndTruncatedJSON(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe"})
+	c := srv.Client()
+
+	srv.InjectFault(lighthousetest.InvalidJSON("/api/v2/probes", 1))
+	_, err := v2.New(c).Probes().Get()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode JSON response")
+
+	srv.InjectFault(lighthousetest.TruncatedJSON("/api/v2/probes", 1))
+	_, err = v2.New(c).Probes().Get()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode JSON response")
+
+	_, err = v2.New(c).Probes().Get()
+	require.NoError(t, err)
+}
+
+func TestFaults_ConnectionReset(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	srv.InjectFault(lighthousetest.ConnectionReset("/api/v2/probes", 0))
+
+	// Disable retries so the reset surfaces immediately.
+	c := srv.Client()
+	c.Client = &http.Client{}
+	_, err := v2.New(c).Probes().Get()
+	require.Error(t, err)
+	var apiErr *client.APIError
+	assert.False(t, errors.As(err, &apiErr))
+}
+
+func TestFaults_SlowBody(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe"})
+	srv.InjectFault(lighthousetest.SlowBody("/api/v2/probes", 5*time.Millisecond))
+
+	start := time.Now()
+	resp, err := v2.New(srv.Client()).Probes().Get()
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Greater(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestFaults_Probability(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	srv.SetSeed(42)
+	f := lighthousetest.RateLimited("/api/v2/health", "0", 0)
+	f.Probability = 0.5
+	srv.InjectFault(f)
+
+	c := srv.Client()
+	for i := 0; i < 20; i++ {
+		_, _ = v2.New(c).Health().Get()
+	}
+
+	var faulted, ok int
+	for _, r := range srv.Requests() {
+		if r.Path != "/api/v2/health" {
+			continue
+		}
+		if r.Fault != nil {
+			faulted++
+		} else {
+			ok++
+		}
+	}
+	assert.Greater(t, faulted, 0)
+	assert.Equal(t, 20, ok)
+}
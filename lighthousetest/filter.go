@@ -0,0 +1,84 @@
+package lighthousetest
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// filter is a filter[field] or filter[field][op] query parameter.
+type filter struct {
+	field  string
+	op     string
+	values []string
+}
+
+// parseFilters returns the filters in q.
+func parseFilters(q url.Values) []filter {
+	var filters []filter
+	for key := range q {
+		rest, ok := strings.CutPrefix(key, "filter[")
+		if !ok {
+			continue
+		}
+		field, rest, ok := strings.Cut(rest, "]")
+		if !ok {
+			continue
+		}
+		f := filter{field: field, values: splitParam(q.Get(key))}
+		if op, ok := strings.CutPrefix(rest, "["); ok {
+			f.op = strings.TrimSuffix(op, "]")
+		}
+		filters = append(filters, f)
+	}
+	return filters
+}
+
+// match reports whether rec satisfies the filter. Plain filters match any of
+// their comma separated values; gt, gte, lt and lte compare with the first.
+func (f filter) match(rec Record) bool {
+	v := rec[f.field]
+	if f.op == "" {
+		for _, want := range f.values {
+			if fmt.Sprint(v) == want {
+				return true
+			}
+		}
+		return false
+	}
+	if v == nil || len(f.values) == 0 {
+		return false
+	}
+	c := compareValues(v, f.values[0])
+	switch f.op {
+	case "gt":
+		return c > 0
+	case "gte":
+		return c >= 0
+	case "lt":
+		return c < 0
+	case "lte":
+		return c <= 0
+	}
+	return false
+}
+
+// selectFields keeps only the fields requested with fields[collection], and
+// the relationships in keep, on each record.
+func selectFields(q url.Values, collection string, recs []Record, keep []string) {
+	fields := splitParam(q.Get("fields[" + collection + "]"))
+	if len(fields) == 0 {
+		return
+	}
+	selected := map[string]bool{}
+	for _, f := range append(fields, keep...) {
+		selected[f] = true
+	}
+	for _, rec := range recs {
+		for k := range rec {
+			if !selected[k] {
+				delete(rec, k)
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Code generated by apigen from lighthouse-v2.yaml. DO NOT EDIT.
+
+package lighthousetest
+
+// CompanyFixture returns a company record built from the examples in the API
+// description, ready to be inserted into the store.
+func CompanyFixture() Record {
+	return Record{
+		"name":    "ACME",
+		"website": "https://acme.example",
+		"email":   "security@acme.example",
+	}
+}
+
+// ScanObjectExclusionFixture returns a scan object exclusion record built from
+// the examples in the API description, ready to be inserted into the store.
+func ScanObjectExclusionFixture() Record {
+	return Record{
+		"name":   "Printers",
+		"type":   "ipv4",
+		"value":  "10.0.0.20",
+		"reason": "Fragile device",
+	}
+}
+
+// RescanTargetFixture returns a rescan target record built from the examples in
+// the API description, ready to be inserted into the store.
+func RescanTargetFixture() Record {
+	return Record{
+		"scanobject_id": "9f0c6a2e-3d5b-4c1e-8a7f-2b6d4e8c1a3f",
+		"target":        "https://10.0.0.1:443",
+		"template":      "ssl-dns-names",
+	}
+}
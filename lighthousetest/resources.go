@@ -0,0 +1,182 @@
+package lighthousetest
+
+// Collection names, matching the URL path segments used by the API.
+const (
+	Companies             = "companies"
+	Probes                = "probes"
+	ScannerPlatforms      = "scannerplatforms"
+	ScanObjects           = "scanobjects"
+	ScanObjectExclusions  = "exclusions"
+	Schedules             = "schedules"
+	ScanTasks             = "scan-tasks"
+	RescanTargets         = "rescan-targets"
+	ScanResults           = "scan-results"
+	HostDiscoveries       = "host-discoveries"
+	CrawledURLs           = "crawled-urls"
+	HackerAlertAppliances = "hacker-alert-appliances"
+	HackerAlerts          = "alerts"
+)
+
+// numericIDs lists collections whose generated ids are integers.
+var numericIDs = map[string]bool{
+	RescanTargets: true,
+}
+
+// upsertCollections lists collections whose POST endpoint creates or updates
+// a record by id.
+var upsertCollections = map[string]bool{
+	ScanResults:     true,
+	HostDiscoveries: true,
+	CrawledURLs:     true,
+}
+
+// routes lists the top-level collections served for each API version.
+var routes = map[string]map[string]bool{
+	"v1": {
+		Companies:             true,
+		Probes:                true,
+		ScannerPlatforms:      true,
+		ScanObjects:           true,
+		Schedules:             true,
+		HackerAlertAppliances: true,
+	},
+	"v2": {
+		Companies:             true,
+		Probes:                true,
+		ScannerPlatforms:      true,
+		ScanObjects:           true,
+		Schedules:             true,
+		ScanTasks:             true,
+		ScanResults:           true,
+		HostDiscoveries:       true,
+		CrawledURLs:           true,
+		HackerAlertAppliances: true,
+	},
+}
+
+// child describes a nested collection reachable below a parent record, such
+// as /probes/{id}/schedules. Children belong to the parent when their
+// foreignKey equals the parent id or when they are linked through a pivot,
+// in either direction.
+type child struct {
+	collection string
+	foreignKey string
+}
+
+// children lists the nested collections per parent collection.
+var children = map[string]map[string]child{
+	Companies: {
+		Probes:                {Probes, "company_id"},
+		HackerAlertAppliances: {HackerAlertAppliances, "company_id"},
+		ScanObjects:           {ScanObjects, "company_id"},
+		ScannerPlatforms:      {ScannerPlatforms, "company_id"},
+		Schedules:             {Schedules, "company_id"},
+		ScanTasks:             {ScanTasks, "company_id"},
+	},
+	Probes: {
+		Schedules:   {Schedules, "probe_id"},
+		ScanObjects: {ScanObjects, "probe_id"},
+		ScanTasks:   {ScanTasks, "probe_id"},
+	},
+	ScannerPlatforms: {
+		Schedules:   {Schedules, "scannerplatform_id"},
+		ScanObjects: {ScanObjects, "scannerplatform_id"},
+		ScanTasks:   {ScanTasks, "scannerplatform_id"},
+	},
+	ScanObjects: {
+		ScanObjectExclusions: {ScanObjectExclusions, "scanobject_id"},
+		ScanResults:          {ScanResults, "scanobject_id"},
+		HostDiscoveries:      {HostDiscoveries, "scanobject_id"},
+		ScanTasks:            {ScanTasks, "scanobject_id"},
+	},
+	Schedules: {
+		Probes:    {Probes, "schedule_id"},
+		ScanTasks: {ScanTasks, "schedule_id"},
+	},
+	HackerAlertAppliances: {
+		HackerAlerts: {HackerAlerts, "hacker_alert_appliance_id"},
+	},
+	ScanTasks: {
+		RescanTargets:   {RescanTargets, "scan_task_id"},
+		HostDiscoveries: {HostDiscoveries, "scan_task_id"},
+		ScanResults:     {ScanResults, "scan_task_id"},
+		CrawledURLs:     {CrawledURLs, "scan_task_id"},
+		ScanObjects:     {ScanObjects, "scan_task_id"},
+	},
+}
+
+// relation describes a relationship that can be included with ?with=.
+type relation struct {
+	// key is the JSON field the related data is included under.
+	key string
+	// collection is the related collection.
+	collection string
+	// localKey is set for belongs-to relations: the field on the record
+	// holding the related id.
+	localKey string
+	// foreignKey is set for has-many relations: the field on the related
+	// records holding this record's id.
+	foreignKey string
+}
+
+// relations lists the includable relationships per collection, keyed by the
+// name used in the with parameter.
+var relations = map[string]map[string]relation{
+	Probes: {
+		"company":         {key: "company", collection: Companies, localKey: "company_id"},
+		"scannerplatform": {key: "scannerplatform", collection: ScannerPlatforms, localKey: "scannerplatform_id"},
+	},
+	ScannerPlatforms: {
+		"company":     {key: "company", collection: Companies, localKey: "company_id"},
+		"probe":       {key: "probe", collection: Probes, localKey: "probe_id"},
+		"scanobjects": {key: "scanobjects", collection: ScanObjects, foreignKey: "scannerplatform_id"},
+	},
+	ScanObjects: {
+		"company":         {key: "company", collection: Companies, localKey: "company_id"},
+		"scannerplatform": {key: "scannerplatform", collection: ScannerPlatforms, localKey: "scannerplatform_id"},
+		"exclusions":      {key: "exclusions", collection: ScanObjectExclusions, foreignKey: "scanobject_id"},
+	},
+	Schedules: {
+		"company": {key: "company", collection: Companies, localKey: "company_id"},
+	},
+	ScanTasks: {
+		"company":         {key: "company", collection: Companies, localKey: "company_id"},
+		"scannerplatform": {key: "scannerplatform", collection: ScannerPlatforms, localKey: "scannerplatform_id"},
+		"probe":           {key: "probe", collection: Probes, localKey: "probe_id"},
+		"rescan-targets":  {key: "rescanTargets", collection: RescanTargets, foreignKey: "scan_task_id"},
+	},
+	HackerAlertAppliances: {
+		"company": {key: "company", collection: Companies, localKey: "company_id"},
+	},
+}
+
+// Scope filters records for the scopes query parameter.
+type Scope func(r Record) bool
+
+// defaultScopes returns the scopes registered on a new server.
+func defaultScopes() map[string]map[string]Scope {
+	return map[string]map[string]Scope{
+		Probes: {
+			"online": func(r Record) bool { return r["status"] == "online" },
+		},
+		ScanObjects: {
+			"enabled": func(r Record) bool { return r["enabled"] == true },
+		},
+		Schedules: {
+			"active": func(r Record) bool { return r["active"] == true },
+		},
+		HackerAlertAppliances: {
+			"online": func(r Record) bool { return r["status"] == "online" },
+		},
+		ScanTasks: {
+			"running": func(r Record) bool {
+				return !isEmpty(r["started_at"]) && isEmpty(r["stopped_at"])
+			},
+			"rescans": func(r Record) bool { return r["type"] == "1" },
+		},
+	}
+}
+
+func isEmpty(v interface{}) bool {
+	return v == nil || v == ""
+}
@@ -0,0 +1,773 @@
+// Package lighthousetest provides an in-memory fake of the Lighthouse API for
+// tests. It serves the v1 and v2 endpoints covered by this library from an
+// httptest.Server, including OAuth client credentials, Laravel-style
+// pagination and the with, scopes and sort query parameters.
+package lighthousetest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guardian360/go-lighthouse/client"
+)
+
+// Default credentials accepted by the token endpoint.
+const (
+	DefaultClientID     = "lighthousetest-client"
+	DefaultClientSecret = "lighthousetest-secret"
+)
+
+// defaultPerPage is the page size used when per_page is not given.
+const defaultPerPage = 15
+
+// Server is a fake Lighthouse API server.
+type Server struct {
+	*httptest.Server
+
+	// Store holds the server's data. Tests can seed and inspect it directly.
+	Store *Store
+	// ClientID and ClientSecret are the credentials accepted by the token
+	// endpoint.
+	ClientID     string
+	ClientSecret string
+	// TokenTTL is the lifetime of issued access tokens.
+	TokenTTL time.Duration
+	// BatchEndpoints enables {collection}/batch, which creates (POST),
+	// updates (PUT or PATCH) or deletes (DELETE) many records in one request.
+	BatchEndpoints bool
+
+	mu       sync.Mutex
+	tokens   map[string]time.Time
+	scopes   map[string]map[string]Scope
+	faults   []*Fault
+	requests []RequestLog
+	pcg      *rand.PCG
+	rand     *rand.Rand
+}
+
+// NewServer starts a new fake Lighthouse server. Call Close when done.
+func NewServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewTLSServer starts a new fake Lighthouse server using TLS. Clients must be
+// created with client.WithInsecure(true) or use the server's certificate.
+func NewTLSServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewTLSServer(s)
+	return s
+}
+
+func newServer() *Server {
+	pcg := rand.NewPCG(1, 1)
+	return &Server{
+		Store:        NewStore(),
+		ClientID:     DefaultClientID,
+		ClientSecret: DefaultClientSecret,
+		TokenTTL:     time.Hour,
+		tokens:       map[string]time.Time{},
+		scopes:       defaultScopes(),
+		pcg:          pcg,
+		rand:         rand.New(pcg),
+	}
+}
+
+// Client returns a Lighthouse client that talks to the server and is
+// authenticated with the server's client credentials.
+func (s *Server) Client(opts ...client.Option) *client.Client {
+	return client.New(s.URL, opts...).
+		WithClientCredentials(s.URL+"/oauth/token", s.ClientID, s.ClientSecret)
+}
+
+// RegisterScope makes a named scope available on a collection.
+func (s *Server) RegisterScope(collection, name string, scope Scope) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.scopes[collection] == nil {
+		s.scopes[collection] = map[string]Scope{}
+	}
+	s.scopes[collection][name] = scope
+}
+
+// RevokeTokens invalidates every issued access token, forcing clients to
+// authenticate again.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = map[string]time.Time{}
+}
+
+// ServeHTTP implements http.Handler. Every request is recorded in the
+// request log, and injected faults are applied before normal handling.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f := s.nextFault(r); f != nil {
+		status := s.serveFault(w, r, f)
+		s.logRequest(r, status, f)
+		return
+	}
+	sw := &statusWriter{ResponseWriter: w}
+	s.serve(sw, r)
+	s.logRequest(r, sw.status, nil)
+}
+
+// statusWriter captures the status code written by a handler.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// serve routes a request without fault injection.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "oauth/token":
+		s.handleToken(w, r)
+		return
+	case path == "api/heartbeat":
+		writeJSON(w, http.StatusOK, v1Envelope([]interface{}{}))
+		return
+	case path == "api/v2/heartbeat":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{}})
+		return
+	case path == "api/v2/health":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": "OK"})
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthenticated.")
+		return
+	}
+
+	segments := strings.Split(path, "/")
+	if len(segments) < 3 || segments[0] != "api" || routes[segments[1]] == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	h := &handler{server: s, version: segments[1], w: w, r: r}
+	h.route(segments[2:])
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "client_credentials" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+	if r.PostForm.Get("client_id") != s.ClientID || r.PostForm.Get("client_secret") != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	token := newUUID()
+	s.mu.Lock()
+	s.tokens[token] = time.Now().Add(s.TokenTTL)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, client.TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.TokenTTL / time.Second),
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiry, ok := s.tokens[token]
+	return ok && time.Now().Before(expiry)
+}
+
+func (s *Server) scope(collection, name string) (Scope, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scope, ok := s.scopes[collection][name]
+	return scope, ok
+}
+
+// handler serves a single API request.
+type handler struct {
+	server  *Server
+	version string
+	w       http.ResponseWriter
+	r       *http.Request
+}
+
+func (h *handler) route(segments []string) {
+	collection := segments[0]
+	if !routes[h.version][collection] {
+		h.notFound()
+		return
+	}
+	switch len(segments) {
+	case 1:
+		h.collection(collection, "", child{})
+	case 2:
+		if segments[1] == "batch" && h.server.BatchEndpoints {
+			h.batch(collection)
+			return
+		}
+		h.item(collection, segments[1])
+	case 3:
+		h.nested(collection, segments[1], segments[2])
+	case 4:
+		h.nestedItem(collection, segments[1], segments[2], segments[3])
+	default:
+		h.notFound()
+	}
+}
+
+// collection serves a list endpoint. For nested collections parentID and rel
+// restrict the listing to the parent's children.
+func (h *handler) collection(collection, parentID string, rel child) {
+	switch h.r.Method {
+	case http.MethodGet:
+		h.list(collection, func(r Record) bool {
+			if parentID == "" {
+				return true
+			}
+			return fmt.Sprint(r[rel.foreignKey]) == parentID
+		})
+	case http.MethodPost:
+		data, ok := h.payload()
+		if !ok {
+			return
+		}
+		if parentID != "" {
+			data[rel.foreignKey] = parentID
+		}
+		if upsertCollections[collection] && data.ID() != "" {
+			if rec, ok := h.server.Store.Update(collection, data.ID(), data); ok {
+				h.writeItem(collection, rec, http.StatusOK)
+				return
+			}
+		}
+		h.writeItem(collection, h.server.Store.Insert(collection, data), http.StatusCreated)
+	default:
+		h.methodNotAllowed()
+	}
+}
+
+func (h *handler) item(collection, id string) {
+	rec, ok := h.server.Store.Get(collection, id)
+	if !ok {
+		h.notFound()
+		return
+	}
+	switch h.r.Method {
+	case http.MethodGet:
+		h.writeItem(collection, rec, http.StatusOK)
+	case http.MethodPut, http.MethodPatch:
+		if h.preconditionFailed(rec) {
+			return
+		}
+		data, ok := h.payload()
+		if !ok {
+			return
+		}
+		rec, _ = h.server.Store.Update(collection, id, data)
+		h.writeItem(collection, rec, http.StatusOK)
+	case http.MethodDelete:
+		if h.preconditionFailed(rec) {
+			return
+		}
+		rec, _ = h.server.Store.Delete(collection, id)
+		h.writeItem(collection, rec, http.StatusOK)
+	default:
+		h.methodNotAllowed()
+	}
+}
+
+// batch creates the records in {"data": [...]}, updates the records with the
+// ids of the records in {"data": [...]} or deletes the records with the ids in
+// {"ids": [...]}, skipping ids that do not exist.
+func (h *handler) batch(collection string) {
+	data, ok := h.payload()
+	if !ok {
+		return
+	}
+	recs := []Record{}
+	status := http.StatusOK
+	switch h.r.Method {
+	case http.MethodPost:
+		items, _ := data["data"].([]interface{})
+		for _, item := range items {
+			fields, _ := item.(map[string]interface{})
+			if fields == nil {
+				writeError(h.w, http.StatusUnprocessableEntity, "The given data was invalid.")
+				return
+			}
+			recs = append(recs, Record(fields))
+		}
+		for i, rec := range recs {
+			recs[i] = h.server.Store.Insert(collection, rec)
+		}
+		status = http.StatusCreated
+	case http.MethodPut, http.MethodPatch:
+		items, _ := data["data"].([]interface{})
+		for _, item := range items {
+			fields, _ := item.(map[string]interface{})
+			if fields == nil || fields["id"] == nil {
+				writeError(h.w, http.StatusUnprocessableEntity, "The given data was invalid.")
+				return
+			}
+			if rec, ok := h.server.Store.Update(collection, fmt.Sprint(fields["id"]), Record(fields)); ok {
+				recs = append(recs, rec)
+			}
+		}
+	case http.MethodDelete:
+		ids, _ := data["ids"].([]interface{})
+		for _, id := range ids {
+			if rec, ok := h.server.Store.Delete(collection, fmt.Sprint(id)); ok {
+				recs = append(recs, rec)
+			}
+		}
+	default:
+		h.methodNotAllowed()
+		return
+	}
+	out, ok := h.withRelations(collection, recs)
+	if ok {
+		h.write(status, map[string]interface{}{"data": out})
+	}
+}
+
+func (h *handler) nested(collection, id, sub string) {
+	parent, ok := h.server.Store.Get(collection, id)
+	if !ok {
+		h.notFound()
+		return
+	}
+
+	if collection == ScanTasks && h.r.Method == http.MethodPost {
+		switch sub {
+		case "start":
+			h.setTimestamp(collection, id, "started_at", Record{"stopped_at": nil, "error": nil})
+			return
+		case "stop":
+			h.setTimestamp(collection, id, "stopped_at", nil)
+			return
+		case ScanObjects:
+			h.associate(collection, parent, ScanObjects, true)
+			return
+		}
+	}
+	if collection == ScanTasks && h.r.Method == http.MethodDelete && sub == ScanObjects {
+		h.associate(collection, parent, ScanObjects, false)
+		return
+	}
+
+	rel, ok := children[collection][sub]
+	if !ok || (h.version == "v1" && !routes["v1"][rel.collection]) {
+		h.notFound()
+		return
+	}
+	if h.r.Method == http.MethodGet {
+		store := h.server.Store
+		h.list(rel.collection, func(r Record) bool {
+			return fmt.Sprint(r[rel.foreignKey]) == id ||
+				store.Linked(collection, id, rel.collection, r.ID()) ||
+				store.Linked(rel.collection, r.ID(), collection, id)
+		})
+		return
+	}
+	h.collection(rel.collection, id, rel)
+}
+
+// nestedItem serves a single child record below its parent, such as
+// /scanobjects/{id}/exclusions/{exclusion}.
+func (h *handler) nestedItem(collection, id, sub, childID string) {
+	rel, ok := children[collection][sub]
+	if !ok || (h.version == "v1" && !routes["v1"][rel.collection]) {
+		h.notFound()
+		return
+	}
+	if _, ok := h.server.Store.Get(collection, id); !ok {
+		h.notFound()
+		return
+	}
+	rec, ok := h.server.Store.Get(rel.collection, childID)
+	if !ok || fmt.Sprint(rec[rel.foreignKey]) != id {
+		h.notFound()
+		return
+	}
+	h.item(rel.collection, childID)
+}
+
+func (h *handler) setTimestamp(collection, id, field string, extra Record) {
+	fields := Record{field: h.server.Store.Now().UTC().Format(TimeFormat)}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	rec, _ := h.server.Store.Update(collection, id, fields)
+	h.writeItem(collection, rec, http.StatusOK)
+}
+
+// associate links the records posted as {"ids": [...]} to parent, or unlinks
+// them if link is false.
+func (h *handler) associate(collection string, parent Record, childCollection string, link bool) {
+	var body struct {
+		IDs []string `json:"ids"`
+	}
+	if err := json.NewDecoder(h.r.Body).Decode(&body); err != nil {
+		writeError(h.w, http.StatusUnprocessableEntity, "The ids field is required.")
+		return
+	}
+	for _, childID := range body.IDs {
+		if _, ok := h.server.Store.Get(childCollection, childID); !ok {
+			writeError(h.w, http.StatusUnprocessableEntity, "The selected ids is invalid.")
+			return
+		}
+	}
+	for _, childID := range body.IDs {
+		if link {
+			h.server.Store.Link(collection, parent.ID(), childCollection, childID)
+		} else {
+			h.server.Store.Unlink(collection, parent.ID(), childCollection, childID)
+		}
+	}
+	h.writeItem(collection, parent, http.StatusOK)
+}
+
+// list writes a filtered, scoped, sorted and paginated listing.
+func (h *handler) list(collection string, include func(Record) bool) {
+	q := h.r.URL.Query()
+
+	var scopes []Scope
+	for _, name := range splitParam(q.Get("scopes")) {
+		scope, ok := h.server.scope(collection, name)
+		if !ok {
+			writeError(h.w, http.StatusBadRequest, fmt.Sprintf("Requested scope(s) `%s` are not allowed.", name))
+			return
+		}
+		scopes = append(scopes, scope)
+	}
+
+	filters := parseFilters(q)
+
+	var recs []Record
+	for _, rec := range h.server.Store.All(collection) {
+		if !include(rec) {
+			continue
+		}
+		matched := true
+		for _, scope := range scopes {
+			if !scope(rec) {
+				matched = false
+				break
+			}
+		}
+		for _, f := range filters {
+			if matched && !f.match(rec) {
+				matched = false
+			}
+		}
+		if matched {
+			recs = append(recs, rec)
+		}
+	}
+	if s := q.Get("sort"); s != "" && !sortRecords(recs, s) {
+		writeError(h.w, http.StatusBadRequest, fmt.Sprintf("Requested sort `%s` is not allowed.", s))
+		return
+	}
+
+	// The fake v1 API only paginates when asked to.
+	if h.version == "v1" && !q.Has("page") && !q.Has("per_page") {
+		data, ok := h.withRelations(collection, recs)
+		if ok {
+			h.write(http.StatusOK, v1Envelope(data))
+		}
+		return
+	}
+
+	page, perPage := positiveInt(q.Get("page"), 1), positiveInt(q.Get("per_page"), defaultPerPage)
+	total := len(recs)
+	lastPage := int(math.Max(1, math.Ceil(float64(total)/float64(perPage))))
+	from, to := (page-1)*perPage, page*perPage
+	if from > total {
+		from = total
+	}
+	if to > total {
+		to = total
+	}
+	data, ok := h.withRelations(collection, recs[from:to])
+	if !ok {
+		return
+	}
+
+	body := map[string]interface{}{
+		"data":  data,
+		"links": h.links(page, lastPage),
+		"meta":  h.meta(page, lastPage, perPage, total, from, to),
+	}
+	if h.version == "v1" {
+		body["success"], body["message"] = true, ""
+	}
+	h.write(http.StatusOK, body)
+}
+
+func (h *handler) writeItem(collection string, rec Record, status int) {
+	data, ok := h.withRelations(collection, []Record{rec})
+	if !ok {
+		return
+	}
+	if h.version == "v1" {
+		h.write(status, v1Envelope(data[0]))
+		return
+	}
+	h.write(status, map[string]interface{}{"data": data[0]})
+}
+
+// withRelations includes the relationships requested with ?with=. v1 wraps
+// every relationship in a {"data": ...} envelope.
+func (h *handler) withRelations(collection string, recs []Record) ([]Record, bool) {
+	names := splitParam(h.r.URL.Query().Get("with"))
+	out := make([]Record, len(recs))
+	for i, rec := range recs {
+		out[i] = rec.clone()
+	}
+	for _, name := range names {
+		rel, ok := relations[collection][name]
+		if !ok {
+			writeError(h.w, http.StatusBadRequest, fmt.Sprintf("Requested include(s) `%s` are not allowed.", name))
+			return nil, false
+		}
+		for _, rec := range out {
+			var value interface{}
+			if rel.localKey != "" {
+				related, found := h.server.Store.Get(rel.collection, fmt.Sprint(rec[rel.localKey]))
+				if found {
+					value = related
+				}
+			} else {
+				many := []Record{}
+				for _, related := range h.server.Store.All(rel.collection) {
+					if fmt.Sprint(related[rel.foreignKey]) == rec.ID() {
+						many = append(many, related)
+					}
+				}
+				value = many
+			}
+			if h.version == "v1" {
+				value = map[string]interface{}{"data": value}
+			}
+			rec[rel.key] = value
+		}
+	}
+	var keep []string
+	for _, name := range names {
+		keep = append(keep, relations[collection][name].key)
+	}
+	selectFields(h.r.URL.Query(), collection, out, keep)
+	return out, true
+}
+
+func (h *handler) pageURL(page int) string {
+	u := *h.r.URL
+	u.Scheme, u.Host = "http", h.r.Host
+	if h.r.TLS != nil {
+		u.Scheme = "https"
+	}
+	q := u.Query()
+	q.Set("page", strconv.Itoa(page))
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func (h *handler) links(page, lastPage int) map[string]interface{} {
+	links := map[string]interface{}{
+		"first": h.pageURL(1),
+		"last":  h.pageURL(lastPage),
+		"prev":  nil,
+		"next":  nil,
+	}
+	if page > 1 {
+		links["prev"] = h.pageURL(page - 1)
+	}
+	if page < lastPage {
+		links["next"] = h.pageURL(page + 1)
+	}
+	return links
+}
+
+func (h *handler) meta(page, lastPage, perPage, total, from, to int) map[string]interface{} {
+	metaLinks := []map[string]interface{}{}
+	for p := 1; p <= lastPage; p++ {
+		metaLinks = append(metaLinks, map[string]interface{}{
+			"url":    h.pageURL(p),
+			"label":  strconv.Itoa(p),
+			"active": p == page,
+		})
+	}
+	u := *h.r.URL
+	u.Scheme, u.Host, u.RawQuery = "http", h.r.Host, ""
+	meta := map[string]interface{}{
+		"current_page": page,
+		"from":         nil,
+		"last_page":    lastPage,
+		"links":        metaLinks,
+		"path":         u.String(),
+		"per_page":     perPage,
+		"to":           nil,
+		"total":        total,
+	}
+	if to > from {
+		meta["from"], meta["to"] = from+1, to
+	}
+	return meta
+}
+
+// write sends a JSON response. Successful GET responses carry a strong ETag,
+// and a matching If-None-Match is answered with 304 Not Modified.
+func (h *handler) write(status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeError(h.w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if h.r.Method == http.MethodGet && status == http.StatusOK {
+		etag := etagOf(body)
+		h.w.Header().Set("ETag", etag)
+		if h.r.Header.Get("If-None-Match") == etag {
+			h.w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	h.w.Header().Set("Content-Type", "application/json")
+	h.w.WriteHeader(status)
+	_, _ = h.w.Write(body)
+}
+
+// etagOf returns the strong ETag of a response body.
+func etagOf(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// preconditionFailed checks the If-Match header of a write against the ETag
+// of rec as served by a plain GET, or without If-Match, the
+// If-Unmodified-Since header against its updated_at, and answers 412
+// Precondition Failed if it does not match.
+func (h *handler) preconditionFailed(rec Record) bool {
+	ifMatch := h.r.Header.Get("If-Match")
+	if ifMatch == "" {
+		since, err := http.ParseTime(h.r.Header.Get("If-Unmodified-Since"))
+		updated, _ := time.Parse(TimeFormat, fmt.Sprint(rec["updated_at"]))
+		if err != nil || !updated.Truncate(time.Second).After(since) {
+			return false
+		}
+		writeError(h.w, http.StatusPreconditionFailed, "Precondition Failed")
+		return true
+	}
+	if ifMatch == "*" {
+		return false
+	}
+	var v interface{} = map[string]interface{}{"data": rec}
+	if h.version == "v1" {
+		v = v1Envelope(rec)
+	}
+	body, err := json.Marshal(v)
+	if err == nil && etagOf(body) == ifMatch {
+		return false
+	}
+	writeError(h.w, http.StatusPreconditionFailed, "Precondition Failed")
+	return true
+}
+
+func (h *handler) payload() (Record, bool) {
+	data := Record{}
+	if h.r.Body == nil {
+		return data, true
+	}
+	if err := json.NewDecoder(h.r.Body).Decode(&data); err != nil && !errors.Is(err, io.EOF) {
+		writeError(h.w, http.StatusUnprocessableEntity, "The given data was invalid.")
+		return nil, false
+	}
+	return data, true
+}
+
+func (h *handler) notFound() {
+	writeError(h.w, http.StatusNotFound, "Not Found")
+}
+
+func (h *handler) methodNotAllowed() {
+	writeError(h.w, http.StatusMethodNotAllowed, "Method Not Allowed")
+}
+
+func v1Envelope(data interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"success": true,
+		"message": "",
+		"data":    data,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+func splitParam(v string) []string {
+	if v == "" {
+		return nil
+	}
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func positiveInt(v string, def int) int {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,763 @@
+package lighthousetest_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/guardian360/go-lighthouse/api"
+	v1 "github.com/guardian360/go-lighthouse/api/v1"
+	v2 "github.com/guardian360/go-lighthouse/api/v2"
+	"github.com/guardian360/go-lighthouse/client"
+	"github.com/guardian360/go-lighthouse/lighthousetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_V2Pagination(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	for i := 1; i <= 20; i++ {
+		srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": fmt.Sprintf("probe-%02d", i)})
+	}
+
+	resp, err := v2.New(srv.Client()).Probes().PerPage(5).Page(2).Sort("name", "desc").Get()
+	require.NoError(t, err)
+
+	require.Len(t, resp.Data, 5)
+	assert.Equal(t, "probe-15", resp.Data[0].Name)
+	assert.Equal(t, 2, resp.Meta.CurrentPage)
+	assert.Equal(t, 4, resp.Meta.LastPage)
+	assert.Equal(t, 20, resp.Meta.Total)
+	assert.Equal(t, 6, resp.Meta.From)
+	assert.Equal(t, 10, resp.Meta.To)
+	assert.NotEmpty(t, resp.Links.Prev)
+	assert.NotEmpty(t, resp.Links.Next)
+}
+
+func TestServer_V2WithAndScopes(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	company := srv.Store.Insert(lighthousetest.Companies, lighthousetest.Record{"name": "ACME"})
+	srv.Store.Insert(lighthousetest.Schedules, lighthousetest.Record{"name": "on", "active": true, "company_id": company.ID()})
+	srv.Store.Insert(lighthousetest.Schedules, lighthousetest.Record{"name": "off", "active": false})
+
+	c := srv.Client()
+	resp, err := v2.New(c).Schedules().Scopes("active").With("company").Get()
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	require.NotNil(t, resp.Data[0].Company)
+	assert.Equal(t, "ACME", resp.Data[0].Company.Name)
+
+	_, err = v2.NewSchedulesAPI(c).Scopes("unknown").Get()
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 400, apiErr.StatusCode)
+}
+
+func TestServer_V2ScanTaskLifecycle(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	lh := v2.New(srv.Client())
+	obj := srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": "web", "type": "url"})
+
+	created, err := lh.ScanTasks().Create(map[string]interface{}{"type": "0"})
+	require.NoError(t, err)
+	id := created.Data.ID
+	require.NotEmpty(t, id)
+
+	_, err = lh.ScanTask(id).AssociateScanObjects([]string{obj.ID()})
+	require.NoError(t, err)
+	assert.True(t, srv.Store.Linked(lighthousetest.ScanTasks, id, lighthousetest.ScanObjects, obj.ID()))
+
+	started, err := lh.ScanTask(id).Start()
+	require.NoError(t, err)
+	assert.NotEmpty(t, started.Data.StartedAt)
+	assert.Equal(t, api.ScanTaskStateRunning, started.Data.State())
+
+	_, err = lh.ScanTask(id).ScanResults().Upsert(map[string]interface{}{"host": "10.0.0.1", "template_id": "t-1"})
+	require.NoError(t, err)
+
+	results, err := lh.ScanTask(id).ScanResults().Get()
+	require.NoError(t, err)
+	require.Len(t, results.Data, 1)
+	assert.Equal(t, "10.0.0.1", results.Data[0].Host)
+
+	stopped, err := lh.ScanTask(id).Stop()
+	require.NoError(t, err)
+	assert.NotEmpty(t, stopped.Data.StoppedAt)
+	assert.Equal(t, api.ScanTaskStateStopped, stopped.Data.State())
+
+	health, err := lh.Health().Get()
+	require.NoError(t, err)
+	assert.Equal(t, "OK", health.Data)
+}
+
+func TestServer_V1CompanyProbes(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	lh := v1.New(srv.Client())
+	company, err := lh.Companies().Create(map[string]interface{}{"name": "ACME"})
+	require.NoError(t, err)
+	require.True(t, company.Success)
+
+	_, err = lh.Company(company.Data.ID).Probes().Create(map[string]interface{}{"name": "probe"})
+	require.NoError(t, err)
+	srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "other"})
+
+	probes, err := lh.Company(company.Data.ID).Probes().Get()
+	require.NoError(t, err)
+	require.Len(t, probes.Data, 1)
+	assert.Equal(t, "probe", probes.Data[0].Name)
+
+	_, err = lh.Heartbeat().Get()
+	require.NoError(t, err)
+}
+
+func TestServer_V1PaginationAndFilters(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	lh := v1.New(srv.Client())
+	probe := srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe"})
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": name, "probe_id": probe.ID()})
+	}
+	srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": "other"})
+
+	page, err := lh.Probe(probe.ID()).ScanObjects().SortBy(v1.ScanObjectName.Desc()).PerPage(2).Page(2).Get()
+	require.NoError(t, err)
+	require.True(t, page.Success)
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, "c", page.Data[0].Name)
+	assert.Equal(t, 3, page.Meta.LastPage)
+
+	unpaged, err := lh.ScanObjects().Where(v1.ScanObjectName.In("a", "other")).Get()
+	require.NoError(t, err)
+	assert.Len(t, unpaged.Data, 2)
+	assert.Zero(t, unpaged.Meta.LastPage)
+
+	var names []string
+	for obj, err := range lh.Probe(probe.ID()).ScanObjects().PerPage(2).All() {
+		require.NoError(t, err)
+		names = append(names, obj.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, names)
+}
+
+func TestServer_V1Relations(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	company := srv.Store.Insert(lighthousetest.Companies, lighthousetest.Record{"name": "ACME"})
+	platform := srv.Store.Insert(lighthousetest.ScannerPlatforms, lighthousetest.Record{"description": "edge", "company_id": company.ID()})
+	probe := srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe", "company_id": company.ID()})
+	srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": "web", "scannerplatform_id": platform.ID()})
+
+	c := srv.Client(client.WithStrictMode(client.StrictLog))
+	lh := v1.New(c)
+	resp, err := lh.Probe(probe.ID()).With("company", "scannerplatform").Get()
+	require.NoError(t, err)
+	owner, ok := resp.Data.Company.Get()
+	require.True(t, ok)
+	assert.Equal(t, "ACME", owner.Name)
+	assert.False(t, resp.Data.ScannerPlatform.Loaded())
+
+	plain, err := lh.Probe(probe.ID()).Get()
+	require.NoError(t, err)
+	assert.False(t, plain.Data.Company.Loaded())
+
+	platforms, err := v1.NewScannerPlatformsAPI(c).With("scanobjects").Get()
+	require.NoError(t, err)
+	require.Len(t, platforms.Data, 1)
+	require.Equal(t, 1, platforms.Data[0].ScanObjects.Len())
+	assert.Equal(t, "web", platforms.Data[0].ScanObjects.Items()[0].Name)
+
+	var types []string
+	for _, d := range c.Drift.Types() {
+		types = append(types, d.Type)
+	}
+	assert.Contains(t, types, "v1.Company")
+	assert.Contains(t, types, "v1.ScanObject")
+}
+
+func TestServer_RejectsUnauthenticatedRequests(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	_, err := v2.New(client.New(srv.URL)).Probes().Get()
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.True(t, apiErr.IsUnauthorized())
+
+	srv.ClientSecret = "rotated"
+	_, err = v2.New(client.New(srv.URL).WithClientCredentials(srv.URL+"/oauth/token", srv.ClientID, "stale")).Probes().Get()
+	require.Error(t, err)
+}
+
+func TestServer_NotFound(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	_, err := v2.New(srv.Client()).Probe("missing").Get()
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.True(t, apiErr.IsNotFound())
+}
+
+func TestServer_ConditionalRequests(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe"})
+
+	lh := v2.New(srv.Client(client.WithCache(client.NewCache(0))))
+	for i := 0; i < 2; i++ {
+		resp, err := lh.Probes().Get()
+		require.NoError(t, err)
+		require.Len(t, resp.Data, 1)
+	}
+
+	reqs := srv.Requests()
+	require.Len(t, reqs, 3)
+	assert.Equal(t, 200, reqs[1].Status)
+	assert.Equal(t, 304, reqs[2].Status)
+}
+
+func TestServer_StrictModeReportsDrift(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	id := srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe", "firmware": "1.2"}).ID()
+
+	c := srv.Client(client.WithStrictMode(client.StrictLog))
+	resp, err := v2.New(c).Probe(id).Get()
+	require.NoError(t, err)
+	assert.JSONEq(t, `"1.2"`, string(resp.Data.Extra["firmware"]))
+
+	drift := c.Drift.Types()
+	require.Len(t, drift, 1)
+	assert.Equal(t, "v2.Probe", drift[0].Type)
+	assert.Equal(t, map[string]int{"firmware": 1}, drift[0].Unknown)
+	assert.Contains(t, drift[0].Missing, "memory")
+
+	c = srv.Client(client.WithStrictMode(client.StrictError))
+	_, err = v2.New(c).Probe(id).Get()
+	var driftErr *client.DriftError
+	require.ErrorAs(t, err, &driftErr)
+	assert.Equal(t, []string{"firmware"}, driftErr.Unknown)
+}
+
+func TestServer_GenericResources(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	type widget struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	widgets := api.NewCollection[widget](c, c.BaseURL+"/api/v2/probes")
+	created, err := widgets.Create(api.APIRequestPayload{"name": "custom"})
+	require.NoError(t, err)
+
+	list, err := api.NewCollection[widget](c, c.BaseURL+"/api/v2/probes").PerPage(10).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []widget{created.Data}, list.Data)
+	assert.Equal(t, 1, list.Meta.Total)
+
+	item := api.NewItem[widget](c, c.BaseURL+"/api/v2/probes/"+created.Data.ID, created.Data.ID)
+	updated, err := item.Update(api.APIRequestPayload{"name": "renamed"})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated.Data.Name)
+
+	_, err = item.Delete()
+	require.NoError(t, err)
+	_, err = item.Get()
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 404, apiErr.StatusCode)
+}
+
+func TestServer_GeneratedResources(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	lh := v2.New(srv.Client())
+	company, err := lh.Companies().Create(api.APIRequestPayload(lighthousetest.CompanyFixture()))
+	require.NoError(t, err)
+	assert.Equal(t, "ACME", company.Data.Name)
+
+	object := srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": "office"})
+	exclusion, err := lh.ScanObject(object.ID()).Exclusions().Create(api.APIRequestPayload(lighthousetest.ScanObjectExclusionFixture()))
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.20", exclusion.Data.Value)
+
+	exclusions, err := lh.ScanObject(object.ID()).Exclusions().SortBy(v2.ScanObjectExclusionName.Asc()).Get()
+	require.NoError(t, err)
+	require.Len(t, exclusions.Data, 1)
+
+	updated, err := lh.ScanObject(object.ID()).Exclusion(exclusion.Data.ID).Update(map[string]interface{}{"reason": "Decommissioned"})
+	require.NoError(t, err)
+	assert.Equal(t, "Decommissioned", updated.Data.Reason)
+
+	_, err = lh.ScanObject("other").Exclusion(exclusion.Data.ID).Get()
+	require.Error(t, err)
+
+	task := srv.Store.Insert(lighthousetest.ScanTasks, lighthousetest.Record{"name": "task"})
+	target, err := lh.ScanTask(task.ID()).RescanTargets().Create(api.APIRequestPayload(lighthousetest.RescanTargetFixture()))
+	require.NoError(t, err)
+	_, err = lh.ScanTask(task.ID()).RescanTarget(fmt.Sprint(target.Data.ID)).Delete()
+	require.NoError(t, err)
+}
+
+func TestServer_V2Filters(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	srv.Store.Insert(lighthousetest.ScanResults, lighthousetest.Record{"host": "10.0.0.1", "port": "443", "matched_at": "2024-01-01T00:00:00.000000Z"})
+	srv.Store.Insert(lighthousetest.ScanResults, lighthousetest.Record{"host": "10.0.0.1", "port": "80", "matched_at": "2024-03-01T00:00:00.000000Z"})
+	srv.Store.Insert(lighthousetest.ScanResults, lighthousetest.Record{"host": "10.0.0.2", "port": "22", "matched_at": "2024-02-01T00:00:00.000000Z"})
+
+	lh := v2.New(srv.Client())
+	results, err := lh.ScanResults().
+		Where(v2.ScanResultHost.Eq("10.0.0.1")).
+		SortBy(v2.ScanResultMatchedAt.Desc()).
+		Get()
+	require.NoError(t, err)
+	require.Len(t, results.Data, 2)
+	assert.Equal(t, "80", results.Data[0].Port.String())
+	assert.Equal(t, "443", results.Data[1].Port.String())
+
+	since, err := api.ParseTime("2024-02-01T00:00:00.000000Z")
+	require.NoError(t, err)
+	results, err = lh.ScanResults().
+		Where(v2.ScanResultMatchedAt.Gte(since)).
+		SortBy(v2.ScanResultHost.Asc(), v2.ScanResultPort.Asc()).
+		Fields(v2.ScanResultID, v2.ScanResultHost).
+		Get()
+	require.NoError(t, err)
+	require.Len(t, results.Data, 2)
+	assert.Equal(t, "10.0.0.1", results.Data[0].Host)
+	assert.Equal(t, "10.0.0.2", results.Data[1].Host)
+	assert.Empty(t, results.Data[0].Port.String())
+}
+
+func TestServer_RejectsMalformedSort(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	for _, sort := range []string{"-", "name,", ",name", "--name", "name,,host"} {
+		_, err := v2.New(srv.Client()).Probes().Sort(sort, "").Get()
+		var apiErr *client.APIError
+		require.True(t, errors.As(err, &apiErr), sort)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode, sort)
+	}
+}
+
+func TestServer_V2Summaries(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	result := srv.Store.Insert(lighthousetest.ScanResults, lighthousetest.Record{
+		"host":             "10.0.0.1",
+		"template_id":      "ssl-dns-names",
+		"info":             map[string]interface{}{"severity": "info"},
+		"request":          "GET / HTTP/1.1",
+		"response":         "HTTP/1.1 200 OK",
+		"template_encoded": "aWQ6IHNzbC1kbnMtbmFtZXM=",
+	})
+	srv.Store.Insert(lighthousetest.ScanResults, lighthousetest.Record{"host": "10.0.0.2"})
+	srv.Store.Insert(lighthousetest.CrawledURLs, lighthousetest.Record{"request": map[string]interface{}{"url": "https://10.0.0.1/"}})
+
+	lh := v2.New(srv.Client())
+	summaries, err := api.GetAs[v2.ScanResultSummary](lh.ScanResults().Where(v2.ScanResultHost.Eq("10.0.0.1")))
+	require.NoError(t, err)
+	require.Len(t, summaries.Data, 1)
+	assert.Equal(t, "info", summaries.Data[0].Severity())
+	assert.Empty(t, summaries.Data[0].Extra)
+
+	full, err := lh.ScanResult(summaries.Data[0].ID).Get()
+	require.NoError(t, err)
+	assert.Equal(t, result.ID(), full.Data.ID)
+	assert.Equal(t, "HTTP/1.1 200 OK", full.Data.Response)
+
+	all, err := lh.ScanResults().Summaries()
+	require.NoError(t, err)
+	assert.Len(t, all.Data, 2)
+
+	urls, err := lh.CrawledURLs().Summaries()
+	require.NoError(t, err)
+	require.Len(t, urls.Data, 1)
+	assert.Empty(t, urls.Data[0].Extra)
+	assert.Equal(t, "https://10.0.0.1/", urls.Data[0].URL())
+}
+
+func TestServer_V2Companies(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	lh := v2.New(srv.Client())
+	created, err := lh.Companies().Create(map[string]interface{}{"name": "ACME"})
+	require.NoError(t, err)
+	id := created.Data.ID
+	srv.Store.Insert(lighthousetest.Companies, lighthousetest.Record{"name": "Globex"})
+
+	page, err := lh.Companies().SortBy(v2.CompanyName.Desc()).PerPage(1).Get()
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "Globex", page.Data[0].Name)
+	assert.Equal(t, 2, page.Meta.LastPage)
+
+	updated, err := lh.Company(id).Update(map[string]interface{}{"email": "security@acme.example"})
+	require.NoError(t, err)
+	assert.Equal(t, "security@acme.example", updated.Data.Email)
+
+	company := lh.Company(id)
+	_, err = company.Probes().Create(map[string]interface{}{"name": "probe"})
+	require.NoError(t, err)
+	srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "other"})
+
+	probes, err := company.Probes().Get()
+	require.NoError(t, err)
+	require.Len(t, probes.Data, 1)
+	assert.Equal(t, "probe", probes.Data[0].Name)
+
+	_, err = lh.Company(id).Delete()
+	require.NoError(t, err)
+	_, err = lh.Company(id).Get()
+	require.Error(t, err)
+}
+
+func TestServer_V2ScanObjectExclusions(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	object := srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": "office", "type": "ipv4-range", "value": "10.0.0.0/24"})
+	scanObject := v2.New(srv.Client()).ScanObject(object.ID())
+	created, err := scanObject.Exclusions().Create(map[string]interface{}{"name": "SSH", "type": "port", "value": "22"})
+	require.NoError(t, err)
+	assert.Equal(t, api.ExclusionTypePort, created.Data.Type)
+
+	loaded, err := scanObject.With("exclusions").Get()
+	require.NoError(t, err)
+	e, ok := loaded.Data.Excluded("10.0.0.1:22")
+	assert.True(t, ok)
+	assert.Equal(t, "SSH", e.Name)
+	_, ok = loaded.Data.Excluded("10.0.0.1:443")
+	assert.False(t, ok)
+
+	_, err = scanObject.Exclusion(created.Data.ID).Delete()
+	require.NoError(t, err)
+	exclusions, err := scanObject.Exclusions().Get()
+	require.NoError(t, err)
+	assert.Empty(t, exclusions.Data)
+}
+
+func TestServer_V2RequestRescan(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	probe := srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe"})
+	task := srv.Store.Insert(lighthousetest.ScanTasks, lighthousetest.Record{"type": "0", "probe_id": probe.ID(), "company_id": "acme"})
+	web := srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": "web"})
+	mail := srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": "mail"})
+	for _, r := range []lighthousetest.Record{
+		{"scanobject_id": web.ID(), "template_id": "ssl-dns-names", "url": "https://10.0.0.1:443"},
+		{"scanobject_id": web.ID(), "template_id": "http-missing-security-headers", "url": "https://10.0.0.1/"},
+		{"scanobject_id": mail.ID(), "template_id": "smtp-open-relay", "host": "10.0.0.2", "port": 25},
+	} {
+		r["scan_task_id"] = task.ID()
+		srv.Store.Insert(lighthousetest.ScanResults, r)
+	}
+
+	lh := v2.New(srv.Client())
+	findings, err := lh.ScanTask(task.ID()).ScanResults().Get()
+	require.NoError(t, err)
+
+	rescan, err := lh.ScanTasks().RequestRescan(findings.Data)
+	require.NoError(t, err)
+	assert.True(t, rescan.Data.IsRescan())
+	assert.Equal(t, probe.ID(), rescan.Data.ProbeID)
+	assert.Equal(t, api.ScanTaskStateRunning, rescan.Data.State())
+
+	targets, err := lh.ScanTask(rescan.Data.ID).RescanTargets().Get()
+	require.NoError(t, err)
+	require.Len(t, targets.Data, 3)
+	assert.Equal(t, "10.0.0.2:25", targets.Data[2].Target)
+	assert.Equal(t, "smtp-open-relay", targets.Data[2].Template)
+	assert.True(t, srv.Store.Linked(lighthousetest.ScanTasks, rescan.Data.ID, lighthousetest.ScanObjects, mail.ID()))
+
+	_, err = lh.ScanTasks().RequestRescan([]v2.ScanResult{{ID: "orphan"}})
+	require.Error(t, err)
+
+	srv.InjectFault(lighthousetest.Fault{Kind: lighthousetest.FaultStatus, Path: "/api/v2/scan-tasks/*/start", Status: http.StatusUnprocessableEntity})
+	before := len(srv.Store.All(lighthousetest.ScanTasks))
+	_, err = lh.ScanTasks().RequestRescan(findings.Data)
+	var rescanErr *v2.RescanError
+	require.True(t, errors.As(err, &rescanErr))
+	assert.True(t, rescanErr.Deleted)
+	_, ok := srv.Store.Get(lighthousetest.ScanTasks, rescanErr.ScanTaskID)
+	assert.False(t, ok)
+	assert.Len(t, srv.Store.All(lighthousetest.ScanTasks), before)
+}
+
+func TestServer_V2ScanTaskScanObjects(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	task := srv.Store.Insert(lighthousetest.ScanTasks, lighthousetest.Record{"type": "0"})
+	var ids []string
+	for _, name := range []string{"web", "mail", "vpn"} {
+		ids = append(ids, srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": name}).ID())
+	}
+
+	lh := v2.New(srv.Client())
+	change, err := lh.ScanTask(task.ID()).AssociateScanObjects(ids[:2])
+	require.NoError(t, err)
+	assert.Equal(t, ids[:2], change.Associated)
+
+	objects, err := lh.ScanTask(task.ID()).ScanObjects().Get()
+	require.NoError(t, err)
+	assert.Len(t, objects.Data, 2)
+
+	change, err = lh.ScanTask(task.ID()).DisassociateScanObjects(ids[:1])
+	require.NoError(t, err)
+	assert.Equal(t, ids[:1], change.Disassociated)
+	assert.False(t, srv.Store.Linked(lighthousetest.ScanTasks, task.ID(), lighthousetest.ScanObjects, ids[0]))
+
+	objects, err = lh.ScanTask(task.ID()).ScanObjects().Get()
+	require.NoError(t, err)
+	require.Len(t, objects.Data, 1)
+	assert.Equal(t, ids[1], objects.Data[0].ID)
+}
+
+func TestServer_V2ReverseNavigation(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	object := srv.Store.Insert(lighthousetest.ScanObjects, lighthousetest.Record{"name": "web"})
+	srv.Store.Insert(lighthousetest.ScanResults, lighthousetest.Record{"host": "10.0.0.1", "scanobject_id": object.ID()})
+	srv.Store.Insert(lighthousetest.ScanResults, lighthousetest.Record{"host": "10.0.0.2"})
+	srv.Store.Insert(lighthousetest.HostDiscoveries, lighthousetest.Record{"host": "10.0.0.1", "scanobject_id": object.ID()})
+	task := srv.Store.Insert(lighthousetest.ScanTasks, lighthousetest.Record{"type": "0"})
+	srv.Store.Insert(lighthousetest.ScanTasks, lighthousetest.Record{"type": "0"})
+	srv.Store.Link(lighthousetest.ScanTasks, task.ID(), lighthousetest.ScanObjects, object.ID())
+
+	schedule := srv.Store.Insert(lighthousetest.Schedules, lighthousetest.Record{"name": "nightly"})
+	srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe", "schedule_id": schedule.ID()})
+	srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "other"})
+	scheduled := srv.Store.Insert(lighthousetest.ScanTasks, lighthousetest.Record{"type": "0", "schedule_id": schedule.ID()})
+
+	lh := v2.New(srv.Client())
+	objects, err := lh.ScanObjects().Get()
+	require.NoError(t, err)
+	require.Len(t, objects.Data, 1)
+
+	results, err := lh.ScanObject(object.ID()).ScanResults().Get()
+	require.NoError(t, err)
+	require.Len(t, results.Data, 1)
+	assert.Equal(t, "10.0.0.1", results.Data[0].Host)
+
+	discoveries, err := lh.ScanObject(object.ID()).HostDiscoveries().Get()
+	require.NoError(t, err)
+	assert.Len(t, discoveries.Data, 1)
+
+	tasks, err := lh.ScanObject(object.ID()).ScanTasks().Get()
+	require.NoError(t, err)
+	require.Len(t, tasks.Data, 1)
+	assert.Equal(t, task.ID(), tasks.Data[0].ID)
+
+	probes, err := lh.Schedule(schedule.ID()).Probes().Get()
+	require.NoError(t, err)
+	require.Len(t, probes.Data, 1)
+	assert.Equal(t, "probe", probes.Data[0].Name)
+
+	scheduledTasks, err := lh.Schedule(schedule.ID()).ScanTasks().Get()
+	require.NoError(t, err)
+	require.Len(t, scheduledTasks.Data, 1)
+	assert.Equal(t, scheduled.ID(), scheduledTasks.Data[0].ID)
+}
+
+func TestServer_V2CompanyHackerAlertAppliances(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+
+	company := srv.Store.Insert(lighthousetest.Companies, lighthousetest.Record{"name": "acme"})
+	srv.Store.Insert(lighthousetest.HackerAlertAppliances, lighthousetest.Record{"name": "honeypot", "company_id": company.ID()})
+	srv.Store.Insert(lighthousetest.HackerAlertAppliances, lighthousetest.Record{"name": "other"})
+
+	appliances, err := v2.New(srv.Client()).Company(company.ID()).HackerAlertAppliances().Get()
+	require.NoError(t, err)
+	require.Len(t, appliances.Data, 1)
+	assert.Equal(t, "honeypot", appliances.Data[0].Name)
+}
+
+func TestServer_BulkCreateUpdateAndDelete(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	lh := v2.New(srv.Client())
+
+	inputs := make([]api.APIRequestPayload, 5)
+	for i := range inputs {
+		inputs[i] = api.APIRequestPayload{"name": fmt.Sprintf("object-%d", i)}
+	}
+	created, err := lh.ScanObjects().CreateMany(inputs, api.BulkOptions{Concurrency: 2})
+	require.NoError(t, err)
+	require.Len(t, created, 5)
+	for i, res := range created {
+		assert.Equal(t, fmt.Sprintf("object-%d", i), res.Data.Name)
+		assert.Equal(t, res.Data.ID, res.ID)
+	}
+	assert.Len(t, srv.Store.All(lighthousetest.ScanObjects), 5)
+
+	updated, err := lh.ScanObjects().UpdateMany([]api.BulkUpdate{
+		{ID: created[3].ID, Data: api.APIRequestPayload{"name": "renamed"}},
+	}, api.BulkOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated[0].Data.Name)
+	assert.Equal(t, created[3].ID, updated[0].ID)
+
+	ids := []string{created[0].ID, "missing", created[1].ID}
+	deleted, err := lh.ScanObjects().DeleteMany(ids, api.BulkOptions{})
+	var itemErr *api.BulkItemError
+	require.ErrorAs(t, err, &itemErr)
+	assert.Equal(t, 1, itemErr.Index)
+	assert.Equal(t, "missing", itemErr.ID)
+	assert.NoError(t, deleted[0].Err)
+	assert.NoError(t, deleted[2].Err)
+	assert.Len(t, srv.Store.All(lighthousetest.ScanObjects), 3)
+}
+
+func TestServer_BulkUsesBatchEndpoint(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	lh := v2.New(srv.Client())
+	inputs := []api.APIRequestPayload{{"name": "a"}, {"name": "b"}}
+
+	// Without a batch endpoint the items are sent one at a time.
+	_, err := lh.Schedules().CreateMany(inputs, api.BulkOptions{Batch: true})
+	require.NoError(t, err)
+	assert.Len(t, srv.Requests(), 4)
+
+	srv.BatchEndpoints = true
+	srv.ResetRequests()
+	created, err := lh.Schedules().CreateMany(inputs, api.BulkOptions{Batch: true})
+	require.NoError(t, err)
+	assert.Equal(t, "b", created[1].Data.Name)
+	assert.Equal(t, []string{"POST /api/v2/schedules/batch 201"}, requestLines(srv))
+
+	srv.ResetRequests()
+	updated, err := lh.Schedules().UpdateMany([]api.BulkUpdate{
+		{ID: created[0].ID, Data: api.APIRequestPayload{"name": "c"}},
+		{ID: "missing", Data: api.APIRequestPayload{"name": "d"}},
+	}, api.BulkOptions{Batch: true})
+	require.Error(t, err)
+	assert.Equal(t, "c", updated[0].Data.Name)
+	assert.Error(t, updated[1].Err)
+	assert.Equal(t, []string{"PUT /api/v2/schedules/batch 200"}, requestLines(srv))
+
+	srv.ResetRequests()
+	deleted, err := lh.Schedules().DeleteMany([]string{created[0].ID, "missing"}, api.BulkOptions{Batch: true})
+	require.Error(t, err)
+	assert.NoError(t, deleted[0].Err)
+	assert.Error(t, deleted[1].Err)
+	assert.Equal(t, []string{"DELETE /api/v2/schedules/batch 200"}, requestLines(srv))
+}
+
+// interceptClient runs before on every request and then sends it.
+type interceptClient struct {
+	client.HttpClient
+	before func(r *http.Request)
+}
+
+func (c interceptClient) Do(r *http.Request) (*http.Response, error) {
+	c.before(r)
+	return c.HttpClient.Do(r)
+}
+
+func TestServer_UpdateIfUnchanged(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv.Store.Now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	c := srv.Client()
+	lh := v2.New(c)
+	id := srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe"}).ID()
+
+	read, err := lh.Probe(id).Get()
+	require.NoError(t, err)
+	updated, err := lh.Probe(id).UpdateIfUnchanged(read.Data, api.APIRequestPayload{"name": "renamed"})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated.Data.Name)
+
+	// read.Data is now outdated.
+	_, err = lh.Probe(id).UpdateIfUnchanged(read.Data, api.APIRequestPayload{"name": "stale"})
+	var conflict *api.ConflictError[v2.Probe]
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "renamed", conflict.Current.Name)
+
+	// A change between the check and the write is caught through If-Match.
+	var ifMatch string
+	c.Client = interceptClient{HttpClient: c.Client, before: func(r *http.Request) {
+		if r.Method == http.MethodPut {
+			ifMatch = r.Header.Get("If-Match")
+			srv.Store.Update(lighthousetest.Probes, id, lighthousetest.Record{"name": "concurrent"})
+		}
+	}}
+	_, err = lh.Probe(id).UpdateIfUnchanged(updated.Data, api.APIRequestPayload{"name": "lost"})
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "concurrent", conflict.Current.Name)
+	assert.NotEmpty(t, ifMatch)
+	rec, _ := srv.Store.Get(lighthousetest.Probes, id)
+	assert.Equal(t, "concurrent", rec["name"])
+}
+
+func TestServer_IfUnmodifiedSince(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	srv.Store.Now = func() time.Time { return time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC) }
+	id := srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe"}).ID()
+	c := srv.Client()
+	url := c.BaseURL + "/api/v2/probes/" + id
+
+	_, err := c.DoWithOptions("PUT", url, map[string]interface{}{"name": "stale"},
+		client.WithHeader("If-Unmodified-Since", "Mon, 01 Jan 2024 09:59:59 GMT"))
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusPreconditionFailed, apiErr.StatusCode)
+
+	_, err = c.DoWithOptions("PUT", url, map[string]interface{}{"name": "fresh"},
+		client.WithHeader("If-Unmodified-Since", "Mon, 01 Jan 2024 10:00:00 GMT"))
+	require.NoError(t, err)
+}
+
+func TestServer_V1UpdateIfUnchanged(t *testing.T) {
+	srv := lighthousetest.NewServer()
+	defer srv.Close()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv.Store.Now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	lh := v1.New(srv.Client())
+	id := srv.Store.Insert(lighthousetest.Probes, lighthousetest.Record{"name": "probe"}).ID()
+
+	read, err := lh.Probe(id).Get()
+	require.NoError(t, err)
+	updated, err := lh.Probe(id).UpdateIfUnchanged(read.Data, api.APIRequestPayload{"name": "renamed"})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated.Data.Name)
+
+	_, err = lh.Probe(id).UpdateIfUnchanged(read.Data, api.APIRequestPayload{"name": "stale"})
+	var conflict *api.ConflictError[v1.Probe]
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "renamed", conflict.Current.Name)
+}
@@ -0,0 +1,268 @@
+package lighthousetest
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is a single stored resource, keyed by its JSON field names.
+type Record map[string]interface{}
+
+// ID returns the record's identifier as a string.
+func (r Record) ID() string {
+	if r == nil || r["id"] == nil {
+		return ""
+	}
+	return fmt.Sprint(r["id"])
+}
+
+// clone returns a shallow copy of the record.
+func (r Record) clone() Record {
+	out := make(Record, len(r))
+	for k, v := range r {
+		out[k] = v
+	}
+	return out
+}
+
+// TimeFormat is the timestamp layout the server uses, matching the
+// microsecond precision Laravel emits.
+const TimeFormat = "2006-01-02T15:04:05.000000Z"
+
+// Store is an in-memory, concurrency-safe resource store. Records are kept in
+// insertion order per collection.
+type Store struct {
+	mu          sync.Mutex
+	collections map[string][]Record
+	links       map[string]map[string]bool
+	sequences   map[string]int
+
+	// Now returns the current time for timestamps. It defaults to time.Now
+	// and can be replaced for deterministic tests.
+	Now func() time.Time
+}
+
+// NewStore creates an empty store.
+func NewStore() *Store {
+	return &Store{
+		collections: map[string][]Record{},
+		links:       map[string]map[string]bool{},
+		sequences:   map[string]int{},
+		Now:         time.Now,
+	}
+}
+
+// Insert adds a record to a collection. A missing id is generated (integers
+// for collections with numeric ids, UUIDs otherwise) and created_at and
+// updated_at are set if absent. The stored record is returned.
+func (s *Store) Insert(collection string, r Record) Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := r.clone()
+	if rec.ID() == "" {
+		if numericIDs[collection] {
+			s.sequences[collection]++
+			rec["id"] = s.sequences[collection]
+		} else {
+			rec["id"] = newUUID()
+		}
+	}
+	now := s.Now().UTC().Format(TimeFormat)
+	if _, ok := rec["created_at"]; !ok {
+		rec["created_at"] = now
+	}
+	if _, ok := rec["updated_at"]; !ok {
+		rec["updated_at"] = now
+	}
+	s.collections[collection] = append(s.collections[collection], rec)
+	return rec.clone()
+}
+
+// Get returns a copy of the record with the given id.
+func (s *Store) Get(collection, id string) (Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.index(collection, id); i >= 0 {
+		return s.collections[collection][i].clone(), true
+	}
+	return nil, false
+}
+
+// Update merges fields into the record with the given id and bumps
+// updated_at. The id itself cannot be changed.
+func (s *Store) Update(collection, id string, fields Record) (Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(collection, id)
+	if i < 0 {
+		return nil, false
+	}
+	rec := s.collections[collection][i]
+	for k, v := range fields {
+		if k == "id" {
+			continue
+		}
+		rec[k] = v
+	}
+	rec["updated_at"] = s.Now().UTC().Format(TimeFormat)
+	return rec.clone(), true
+}
+
+// Delete removes the record with the given id and returns it.
+func (s *Store) Delete(collection, id string) (Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(collection, id)
+	if i < 0 {
+		return nil, false
+	}
+	recs := s.collections[collection]
+	rec := recs[i]
+	s.collections[collection] = append(recs[:i:i], recs[i+1:]...)
+	return rec, true
+}
+
+// All returns copies of every record in a collection, in insertion order.
+func (s *Store) All(collection string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Record, 0, len(s.collections[collection]))
+	for _, rec := range s.collections[collection] {
+		out = append(out, rec.clone())
+	}
+	return out
+}
+
+// Link associates a child record with a parent through a pivot, as used for
+// many-to-many relationships such as scan task scan objects.
+func (s *Store) Link(parent, parentID, child, childID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := linkKey(parent, parentID, child)
+	if s.links[key] == nil {
+		s.links[key] = map[string]bool{}
+	}
+	s.links[key][childID] = true
+}
+
+// Unlink removes a pivot association created by Link.
+func (s *Store) Unlink(parent, parentID, child, childID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.links[linkKey(parent, parentID, child)], childID)
+}
+
+// Linked reports whether a child record is associated with a parent.
+func (s *Store) Linked(parent, parentID, child, childID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.links[linkKey(parent, parentID, child)][childID]
+}
+
+func (s *Store) index(collection, id string) int {
+	for i, rec := range s.collections[collection] {
+		if rec.ID() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func linkKey(parent, parentID, child string) string {
+	return parent + "/" + parentID + "/" + child
+}
+
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// sortRecords sorts records in place by a sort parameter of the form "key",
+// "-key" for descending order, or "-key,other" to sort on several keys. It
+// reports false if the parameter is not of that form.
+func sortRecords(recs []Record, param string) bool {
+	type key struct {
+		field string
+		desc  bool
+	}
+	var keys []key
+	for _, part := range strings.Split(param, ",") {
+		field, desc := strings.CutPrefix(part, "-")
+		if field == "" || strings.HasPrefix(field, "-") {
+			return false
+		}
+		keys = append(keys, key{field: field, desc: desc})
+	}
+	sort.SliceStable(recs, func(i, j int) bool {
+		for _, k := range keys {
+			c := compareValues(recs[i][k.field], recs[j][k.field])
+			if c == 0 {
+				continue
+			}
+			if k.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	return true
+}
+
+// compareValues orders numbers numerically and everything else by its string
+// form. Missing values sort first.
+func compareValues(a, b interface{}) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if aok && bok {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}